@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"github.com/oppai/slakctl/internal/apply"
+
+	"github.com/spf13/cobra"
+)
+
+var applyFile string
+
+var planCmd = &cobra.Command{
+	Use:   "plan -f <file>",
+	Short: "Show the changes apply would make",
+	Long:  "Compare a channel configuration file with the workspace and show the changes 'slakctl apply' would make, without changing anything.",
+	Args:  cobra.NoArgs,
+	RunE:  runPlan,
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply -f <file>",
+	Short: "Bring channels in line with a configuration file",
+	Long: `Create and update channels so that they match a YAML configuration file:
+
+  channels:
+    - name: oncall
+      topic: "On call: @alice"
+      purpose: Pager rotation and handoffs
+      members: [alice, bob]
+    - name: old-project
+      archived: true
+
+The changes are shown first and applied after confirmation, unless --yes is given. --yes is required with -f -, as standard input then holds the configuration. Channels not listed in the file are left alone. When members is given it is the complete member list, so other members are removed.`,
+	Args: cobra.NoArgs,
+	RunE: runApply,
+}
+
+func init() {
+	for _, c := range []*cobra.Command{planCmd, applyCmd} {
+		c.Flags().StringVarP(&applyFile, "file", "f", "", "Channel configuration file (- for standard input)")
+		c.MarkFlagRequired("file")
+		rootCmd.AddCommand(c)
+	}
+	applyCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Apply without asking for confirmation")
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	plan, err := buildPlan(cmd)
+	if err != nil {
+		return err
+	}
+
+	plan.Write(cmd.OutOrStdout())
+	return nil
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	if err := requireYesForStdin(applyFile); err != nil {
+		return err
+	}
+
+	plan, err := buildPlan(cmd)
+	if err != nil {
+		return err
+	}
+
+	plan.Write(cmd.OutOrStdout())
+	if plan.IsEmpty() {
+		return nil
+	}
+
+	cmd.Println()
+	if err := confirm(cmd, "Apply these changes?"); err != nil {
+		return err
+	}
+
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	err = plan.Apply(cmd.Context(), client, func(channel, step string) {
+		cmd.Printf("#%s: %s\n", channel, step)
+	})
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("\nApply complete: %d channels changed.\n", len(plan.Changes))
+	return nil
+}
+
+func buildPlan(cmd *cobra.Command) (*apply.Plan, error) {
+	var config *apply.Config
+	var err error
+	if applyFile == "-" {
+		config, err = apply.Read(cmd.InOrStdin())
+	} else {
+		config, err = apply.Load(applyFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := authenticatedClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return apply.Build(cmd.Context(), client, config)
+}
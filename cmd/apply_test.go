@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setupApplyTest(t *testing.T) *[]string {
+	calls, tempDir := newFakeSlack(t, slackMethods{
+		"auth.test": respond(map[string]interface{}{"user_id": "USELF"}),
+		"conversations.list": respond(map[string]interface{}{
+			"channels": []map[string]interface{}{
+				{"id": "CONCALL", "name": "oncall", "topic": map[string]interface{}{"value": "On call: alice"}},
+			},
+		}),
+	})
+
+	applyFile = filepath.Join(tempDir, "channels.yaml")
+	t.Cleanup(func() { applyFile = "" })
+	if err := os.WriteFile(applyFile, []byte("channels:\n  - name: oncall\n    topic: \"On call: bob\"\n"), 0600); err != nil {
+		t.Fatalf("failed to write configuration: %v", err)
+	}
+
+	return calls
+}
+
+func TestPlanCmd(t *testing.T) {
+	calls := setupApplyTest(t)
+
+	output, err := runManageCmd(runPlan, "")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !strings.Contains(output, `~ topic    = "On call: alice" -> "On call: bob"`) || !strings.Contains(output, "Plan: 0 to create, 1 to change, 0 unchanged.") {
+		t.Errorf("unexpected output: %s", output)
+	}
+	if len(*calls) != 0 {
+		t.Errorf("expected no changes, got: %v", *calls)
+	}
+}
+
+func TestApplyCmd(t *testing.T) {
+	t.Run("should apply after confirmation", func(t *testing.T) {
+		calls := setupApplyTest(t)
+
+		output, err := runManageCmd(runApply, "yes\n")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, "Apply these changes? [y/N]") || !strings.Contains(output, "#oncall: topic set") {
+			t.Errorf("unexpected output: %s", output)
+		}
+		if len(*calls) != 1 || !strings.HasPrefix((*calls)[0], "conversations.setTopic ") {
+			t.Errorf("expected conversations.setTopic, got: %v", *calls)
+		}
+	})
+
+	t.Run("should not apply when declined", func(t *testing.T) {
+		calls := setupApplyTest(t)
+
+		if _, err := runManageCmd(runApply, "n\n"); err != errNotConfirmed {
+			t.Errorf("expected abort, got: %v", err)
+		}
+		if len(*calls) != 0 {
+			t.Errorf("expected no changes, got: %v", *calls)
+		}
+	})
+
+	t.Run("should require --yes when reading stdin", func(t *testing.T) {
+		calls := setupApplyTest(t)
+		applyFile = "-"
+
+		_, err := runManageCmd(runApply, "channels:\n  - name: oncall\n    topic: \"On call: bob\"\n")
+		if err == nil || !strings.Contains(err.Error(), "--yes") {
+			t.Errorf("expected error asking for --yes, got: %v", err)
+		}
+		if len(*calls) != 0 {
+			t.Errorf("expected no changes, got: %v", *calls)
+		}
+	})
+
+	t.Run("should read the configuration from the command's stdin", func(t *testing.T) {
+		calls := setupApplyTest(t)
+		applyFile = "-"
+		assumeYes = true
+		defer func() { assumeYes = false }()
+
+		if _, err := runManageCmd(runApply, "channels:\n  - name: oncall\n    topic: \"On call: bob\"\n"); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(*calls) != 1 || !strings.HasPrefix((*calls)[0], "conversations.setTopic ") {
+			t.Errorf("expected conversations.setTopic, got: %v", *calls)
+		}
+	})
+}
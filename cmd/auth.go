@@ -2,9 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
-	"slakctl/internal/config"
-	"slakctl/internal/slack"
+	"github.com/oppai/slakctl/internal/config"
 
 	"github.com/spf13/cobra"
 )
@@ -39,15 +39,22 @@ func runAuth(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("token cannot be empty")
 	}
 
-	client := slack.NewClient(token)
-	if err := client.TestAuth(); err != nil {
+	client := newClient(token)
+	identity, err := client.AuthTestContext(cmd.Context())
+	if err != nil {
 		return fmt.Errorf("authentication failed: %w", err)
 	}
 
-	cfg := &config.Config{
-		Token: token,
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	// 他の設定（OAuth クレデンシャルや app token）は保持する
+	cfg.Token = token
+	// キャッシュをワークスペースごとに分けるために使う
+	cfg.TeamID = identity.TeamID
+
 	if err := config.SaveConfig(cfg); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
@@ -56,7 +63,52 @@ func runAuth(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var authAppTokenCmd = &cobra.Command{
+	Use:   "app-token [token]",
+	Short: "Save an app-level token for Socket Mode",
+	Long:  "Save an app-level token (xapp-) with the connections:write scope. It is used by 'slakctl channel tail' to receive messages in real time over Socket Mode. If no token is provided, you will be prompted to enter one.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runAuthAppToken,
+}
+
+func runAuthAppToken(cmd *cobra.Command, args []string) error {
+	var token string
+
+	if len(args) > 0 {
+		token = args[0]
+	} else {
+		fmt.Print("Enter your Slack app-level token: ")
+		if _, err := fmt.Scanln(&token); err != nil {
+			return fmt.Errorf("failed to read token: %w", err)
+		}
+	}
+
+	if !strings.HasPrefix(token, "xapp-") {
+		return fmt.Errorf("app-level tokens start with 'xapp-'")
+	}
+
+	client := newClient(token)
+	if _, err := client.OpenSocketModeConnection(cmd.Context()); err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	cfg.AppToken = token
+
+	if err := config.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println("App-level token saved.")
+	return nil
+}
+
 func init() {
 	authCmd.AddCommand(authTokenCmd)
+	authCmd.AddCommand(authAppTokenCmd)
 	authCmd.Flags().BoolP("help", "h", false, "Help for auth command")
-}
\ No newline at end of file
+}
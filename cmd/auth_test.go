@@ -2,10 +2,16 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/oppai/slakctl/pkg/slack"
+
 	"github.com/spf13/cobra"
 )
 
@@ -159,4 +165,74 @@ func TestPostCmd(t *testing.T) {
 			t.Errorf("expected authentication error, got: %v", err)
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestPostChannelNames(t *testing.T) {
+	var posted []string
+	newTestWorkspace(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/chat.postMessage":
+			var data map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&data)
+			channel, _ := data["channel"].(string)
+			posted = append(posted, channel)
+		case "/api/conversations.list":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":       true,
+				"channels": []map[string]interface{}{{"id": "C1000001", "name": "general"}},
+			})
+			return
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	})
+
+	t.Run("should post to the ID of a channel name", func(t *testing.T) {
+		for _, ref := range []string{"#general", "general", "C1000001"} {
+			posted = nil
+			if _, err := runManageCmd(runPost, "", ref, "hello"); err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if !reflect.DeepEqual(posted, []string{"C1000001"}) {
+				t.Errorf("%s: unexpected posts: %v", ref, posted)
+			}
+		}
+	})
+
+	t.Run("should suggest channels for unknown names without posting", func(t *testing.T) {
+		posted = nil
+		_, err := runManageCmd(runPost, "", "#genral", "hello")
+
+		var notFound *slack.NotFoundError
+		if !errors.As(err, &notFound) || !reflect.DeepEqual(notFound.Suggestions, []string{"#general"}) {
+			t.Errorf("expected suggestions, got: %v", err)
+		}
+		if len(posted) != 0 {
+			t.Errorf("expected no post, got: %v", posted)
+		}
+	})
+}
+
+func TestAPIURLOverride(t *testing.T) {
+	newTestWorkspace(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/chat.postMessage" {
+			t.Errorf("expected path '/api/chat.postMessage', got: %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	})
+
+	cmd := &cobra.Command{
+		Use:  "post",
+		RunE: runPost,
+	}
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"C1234567890", "Hello, world!"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+}
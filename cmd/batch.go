@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/oppai/slakctl/internal/batch"
+	"github.com/oppai/slakctl/pkg/slack"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	batchFile        string
+	batchResults     string
+	batchConcurrency int
+)
+
+// batchOperation is a channel command that can also run over a list of
+// channels with --from-file.
+type batchOperation struct {
+	// verb and done describe the operation in messages, e.g. "archive"
+	// and "archived".
+	verb string
+	done string
+	// skip returns why channel needs no change, or "".
+	skip func(channel *slack.Channel) string
+	run  func(ctx context.Context, client *slack.Client, channel *slack.Channel) error
+}
+
+var archiveOperation = batchOperation{
+	verb: "archive",
+	done: "archived",
+	skip: func(channel *slack.Channel) string {
+		if channel.IsArchived {
+			return "already archived"
+		}
+		return ""
+	},
+	run: func(ctx context.Context, client *slack.Client, channel *slack.Channel) error {
+		return client.ArchiveChannel(ctx, channel.ID)
+	},
+}
+
+var unarchiveOperation = batchOperation{
+	verb: "unarchive",
+	done: "unarchived",
+	skip: func(channel *slack.Channel) string {
+		if !channel.IsArchived {
+			return "not archived"
+		}
+		return ""
+	},
+	run: func(ctx context.Context, client *slack.Client, channel *slack.Channel) error {
+		return client.UnarchiveChannel(ctx, channel.ID)
+	},
+}
+
+// addBatchFlags adds the --from-file flags to c. c must accept either one
+// channel argument or --from-file.
+func addBatchFlags(c *cobra.Command) {
+	c.Flags().StringVar(&batchFile, "from-file", "", "Read channels (IDs or names, one per line or in the first CSV column) from a file, or - for standard input")
+	c.Flags().StringVar(&batchResults, "results", "", "Where to write the results of --from-file (default <verb>-results-<time>.csv)")
+	c.Flags().IntVar(&batchConcurrency, "concurrency", 4, "Number of channels processed at once with --from-file")
+	c.Args = func(cmd *cobra.Command, args []string) error {
+		if batchFile != "" {
+			if len(args) > 0 {
+				return fmt.Errorf("pass either a channel or --from-file, not both")
+			}
+			return nil
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	}
+}
+
+// runBatch runs op over the channels listed in --from-file. Every channel
+// is reported as it completes, and the results are written to a CSV file
+// that can be passed back to --from-file to retry the failures.
+func runBatch(cmd *cobra.Command, op batchOperation) error {
+	if batchConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	if !dryRun {
+		if err := requireYesForStdin(batchFile); err != nil {
+			return err
+		}
+	}
+
+	items, err := readBatchItems(cmd, batchFile)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		cmd.PrintErrln("No channels to process")
+		return nil
+	}
+
+	if !dryRun {
+		if err := confirm(cmd, fmt.Sprintf("%s %d channels?", capitalize(op.verb), len(items))); err != nil {
+			return err
+		}
+	}
+
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	// 全ワーカーで Resolver を共有し、チャンネル一覧の取得を 1 回にする
+	resolver := slack.NewResolver(client)
+
+	results := batch.Run(cmd.Context(), items, batchConcurrency, func(ctx context.Context, item string) batch.Result {
+		channelID, err := resolver.ResolveChannelID(ctx, item)
+		if err != nil {
+			return batch.Result{Status: batch.StatusFailed, Message: err.Error()}
+		}
+		channel, err := client.GetConversationInfo(ctx, channelID)
+		if err != nil {
+			return batch.Result{ID: channelID, Status: batch.StatusFailed, Message: err.Error()}
+		}
+
+		result := batch.Result{ID: channel.ID, Name: channel.Name}
+		switch reason := op.skip(channel); {
+		case reason != "":
+			result.Status, result.Message = batch.StatusSkipped, reason
+		case dryRun:
+			result.Status, result.Message = batch.StatusOK, "would be "+op.done
+		default:
+			if err := op.run(ctx, client, channel); err != nil {
+				result.Status, result.Message = batch.StatusFailed, err.Error()
+			} else {
+				result.Status, result.Message = batch.StatusOK, op.done
+			}
+		}
+		return result
+	}, func(result batch.Result) {
+		printBatchResult(cmd, result)
+	})
+
+	ok, skipped, failed := batch.Count(results, batch.StatusOK), batch.Count(results, batch.StatusSkipped), batch.Count(results, batch.StatusFailed)
+	summary := fmt.Sprintf("%d skipped, %d failed", skipped, failed)
+	if notRun := batch.Count(results, batch.StatusNotRun); notRun > 0 {
+		summary += fmt.Sprintf(", %d not run", notRun)
+	}
+	if dryRun {
+		cmd.Printf("\nWould %s %d channels, %s\n", op.verb, ok, summary)
+		return cmd.Context().Err()
+	}
+	cmd.Printf("\n%s %d channels, %s\n", capitalize(op.done), ok, summary)
+
+	path := batchResults
+	if path == "" {
+		path = fmt.Sprintf("%s-results-%s.csv", op.verb, time.Now().Format("20060102-150405"))
+	}
+	if err := batch.WriteResults(path, results); err != nil {
+		return err
+	}
+	cmd.PrintErrf("Results written to %s\n", path)
+
+	if err := cmd.Context().Err(); err != nil {
+		// 割り込みの終了コードになるよう ctx のエラーを包んで返す
+		return fmt.Errorf("interrupted; resume with --from-file %s: %w", path, err)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d channels failed; retry them with --from-file %s", failed, len(results), path)
+	}
+	return nil
+}
+
+func readBatchItems(cmd *cobra.Command, path string) ([]string, error) {
+	var r io.Reader
+	if path == "-" {
+		r = cmd.InOrStdin()
+	} else {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer file.Close()
+		r = file
+	}
+	return batch.ReadItems(r)
+}
+
+func printBatchResult(cmd *cobra.Command, result batch.Result) {
+	target := result.Input
+	if result.Name != "" {
+		target = fmt.Sprintf("#%s (%s)", result.Name, result.ID)
+	}
+	cmd.Printf("%-8s %s: %s\n", result.Status, target, result.Message)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// setupBatchTest は CARCHIVED だけがアーカイブ済みで、CBROKEN のアーカイブが
+// 失敗するワークスペースを用意する
+func setupBatchTest(t *testing.T) (*[]string, string) {
+	var archived []string
+	_, tempDir := newFakeSlack(t, slackMethods{
+		"conversations.info": func(r *http.Request) map[string]interface{} {
+			id := r.URL.Query().Get("channel")
+			if id == "CMISSING" {
+				return map[string]interface{}{"ok": false, "error": "channel_not_found"}
+			}
+			return map[string]interface{}{
+				"channel": map[string]interface{}{"id": id, "name": strings.ToLower(id[1:]), "is_archived": id == "CARCHIVED"},
+			}
+		},
+		"conversations.archive": func(r *http.Request) map[string]interface{} {
+			var data map[string]string
+			json.NewDecoder(r.Body).Decode(&data)
+			if data["channel"] == "CBROKEN" {
+				return map[string]interface{}{"ok": false, "error": "restricted_action"}
+			}
+			archived = append(archived, data["channel"])
+			return map[string]interface{}{}
+		},
+	})
+
+	batchResults = filepath.Join(tempDir, "results.csv")
+	t.Cleanup(func() {
+		batchFile = ""
+		batchResults = ""
+		assumeYes = false
+	})
+
+	return &archived, tempDir
+}
+
+func TestChannelArchiveFromFile(t *testing.T) {
+	archived, tempDir := setupBatchTest(t)
+
+	batchFile = filepath.Join(tempDir, "stale.csv")
+	os.WriteFile(batchFile, []byte("id,name\nCOLD001,old1\nCARCHIVED,x\nCBROKEN,y\nCMISSING,z\nCOLD002,old2\n"), 0600)
+	assumeYes = true
+
+	output, err := runManageCmd(runChannelArchive, "")
+	if err == nil || !strings.Contains(err.Error(), "2 of 5 channels failed") {
+		t.Fatalf("expected failure summary, got: %v", err)
+	}
+
+	for _, want := range []string{
+		"ok       #old001 (COLD001): archived",
+		"skipped  #archived (CARCHIVED): already archived",
+		"failed   #broken (CBROKEN): failed to archive channel CBROKEN: conversations.archive: restricted_action",
+		"failed   CMISSING: failed to get channel CMISSING: conversations.info: channel_not_found",
+		"Archived 2 channels, 1 skipped, 2 failed",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+	if len(*archived) != 2 {
+		t.Errorf("expected 2 channels to be archived, got: %v", *archived)
+	}
+
+	t.Run("should retry only failures from the results file", func(t *testing.T) {
+		*archived = nil
+		batchFile = batchResults
+		batchResults = filepath.Join(tempDir, "retry.csv")
+
+		output, _ := runManageCmd(runChannelArchive, "")
+		if strings.Contains(output, "COLD001") || !strings.Contains(output, "CBROKEN") || !strings.Contains(output, "CMISSING") {
+			t.Errorf("expected only failures to be retried, got:\n%s", output)
+		}
+		if len(*archived) != 0 {
+			t.Errorf("expected nothing new to be archived, got: %v", *archived)
+		}
+	})
+}
+
+func TestChannelArchiveFromStdin(t *testing.T) {
+	t.Run("should require --yes when reading stdin", func(t *testing.T) {
+		archived, _ := setupBatchTest(t)
+		batchFile = "-"
+
+		_, err := runManageCmd(runChannelArchive, "COLD001\n")
+		if err == nil || !strings.Contains(err.Error(), "--yes") {
+			t.Errorf("expected error asking for --yes, got: %v", err)
+		}
+		if len(*archived) != 0 {
+			t.Errorf("expected no changes, got: %v", *archived)
+		}
+	})
+
+	t.Run("should preview with --dry-run", func(t *testing.T) {
+		archived, tempDir := setupBatchTest(t)
+		batchFile = "-"
+		dryRun = true
+		defer func() { dryRun = false }()
+
+		output, err := runManageCmd(runChannelArchive, "COLD001\nCOLD002\n")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, "would be archived") || !strings.Contains(output, "Would archive 2 channels") {
+			t.Errorf("unexpected output: %s", output)
+		}
+		if len(*archived) != 0 {
+			t.Errorf("expected no changes, got: %v", *archived)
+		}
+		if _, err := os.Stat(filepath.Join(tempDir, "results.csv")); err == nil {
+			t.Error("expected no results file for --dry-run")
+		}
+	})
+}
+
+func TestChannelArchiveFromFileInterrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, tempDir := newFakeSlack(t, slackMethods{
+		"conversations.info": func(r *http.Request) map[string]interface{} {
+			id := r.URL.Query().Get("channel")
+			return map[string]interface{}{"channel": map[string]interface{}{"id": id, "name": strings.ToLower(id[1:])}}
+		},
+		"conversations.archive": func(*http.Request) map[string]interface{} {
+			// 最初のアーカイブで Ctrl-C を押したことにする
+			cancel()
+			return map[string]interface{}{}
+		},
+	})
+
+	batchFile = filepath.Join(tempDir, "stale.csv")
+	batchResults = filepath.Join(tempDir, "results.csv")
+	os.WriteFile(batchFile, []byte("COLD001\nCOLD002\nCOLD003\n"), 0600)
+	assumeYes = true
+	batchConcurrency = 1
+	defer func() {
+		batchFile = ""
+		batchResults = ""
+		assumeYes = false
+		batchConcurrency = 4
+	}()
+
+	cmd := &cobra.Command{Use: "archive", RunE: runChannelArchive}
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{})
+
+	err := cmd.ExecuteContext(ctx)
+	if ExitCode(err) != ExitInterrupted {
+		t.Fatalf("expected interrupted exit code, got %d: %v", ExitCode(err), err)
+	}
+	if !strings.Contains(buf.String(), "2 not run") {
+		t.Errorf("expected channels left to be counted as not run, got:\n%s", buf.String())
+	}
+
+	results, _ := os.ReadFile(batchResults)
+	if !strings.Contains(string(results), "COLD003,,,not run,") {
+		t.Errorf("expected not run in results file, got:\n%s", results)
+	}
+}
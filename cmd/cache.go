@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/oppai/slakctl/internal/cache"
+	"github.com/oppai/slakctl/internal/config"
+	"github.com/oppai/slakctl/internal/printer"
+	"github.com/oppai/slakctl/internal/timefmt"
+
+	"github.com/spf13/cobra"
+)
+
+var cacheClearAll bool
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local cache",
+	Long:  "Channel, user and emoji lists are cached on disk per workspace so that repeated commands do not fetch them again. Use --refresh on any command to update the cache, or --no-cache to bypass it.",
+}
+
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show cached data for the current workspace",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheStatus,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove cached data for the current workspace",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheClear,
+}
+
+func init() {
+	cacheClearCmd.Flags().BoolVar(&cacheClearAll, "all", false, "Remove cached data for every workspace")
+	cacheCmd.AddCommand(cacheStatusCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
+
+func runCacheStatus(cmd *cobra.Command, args []string) error {
+	c, err := currentCache()
+	if err != nil {
+		return err
+	}
+
+	entries, err := c.Entries()
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Cache directory: %s\n", c.Dir())
+	if len(entries) == 0 {
+		cmd.Println("No cached data")
+		return nil
+	}
+
+	cmd.Println()
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tUPDATED\tSIZE\tSTATUS")
+	for _, entry := range entries {
+		status := "fresh"
+		if entry.Expired {
+			status = "expired"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", entry.Key, timefmt.Default.Format(entry.UpdatedAt), printer.FormatSize(entry.Size), status)
+	}
+	return w.Flush()
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	if cacheClearAll {
+		if err := cache.ClearAll(); err != nil {
+			return err
+		}
+		cmd.Println("Cache cleared for all workspaces")
+		return nil
+	}
+
+	c, err := currentCache()
+	if err != nil {
+		return err
+	}
+	if err := c.Clear(); err != nil {
+		return err
+	}
+
+	cmd.Println("Cache cleared")
+	return nil
+}
+
+// currentCache returns the cache of the workspace the saved token belongs to.
+func currentCache() (*cache.Cache, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.Token == "" {
+		return nil, errNoToken
+	}
+
+	return workspaceCache(cfg)
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/oppai/slakctl/internal/timefmt"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCacheCmd(t *testing.T) {
+	requests := 0
+	newTestWorkspace(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":       true,
+			"channels": []map[string]interface{}{{"id": "C1", "name": "general"}},
+		})
+	})
+
+	run := func(runE func(*cobra.Command, []string) error) string {
+		cmd := &cobra.Command{Use: "test", RunE: runE}
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{})
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		return buf.String()
+	}
+
+	showProgress = false
+	defer func() { showProgress = true }()
+
+	run(runChannelList)
+	run(runChannelList)
+	if requests != 1 {
+		t.Errorf("expected second listing to be served from cache, got %d requests", requests)
+	}
+
+	t.Run("should refetch with --refresh", func(t *testing.T) {
+		refreshCache = true
+		defer func() { refreshCache = false }()
+
+		run(runChannelList)
+		if requests != 2 {
+			t.Errorf("expected a new request, got %d requests", requests)
+		}
+	})
+
+	t.Run("should bypass the cache with --no-cache", func(t *testing.T) {
+		noCache = true
+		defer func() { noCache = false }()
+
+		run(runChannelList)
+		if requests != 3 {
+			t.Errorf("expected a new request, got %d requests", requests)
+		}
+	})
+
+	t.Run("should show cached entries", func(t *testing.T) {
+		output := run(runCacheStatus)
+		if !strings.Contains(output, "channels_public_channel_1000") || !strings.Contains(output, "fresh") {
+			t.Errorf("expected channel entry in status, got: %s", output)
+		}
+	})
+
+	t.Run("should show update times in the --time-format style", func(t *testing.T) {
+		defer func(f timefmt.Formatter) { *timefmt.Default = f }(*timefmt.Default)
+		timefmt.Default.Layout = timefmt.Relative
+
+		output := run(runCacheStatus)
+		if !strings.Contains(output, "just now") {
+			t.Errorf("expected relative update time, got: %s", output)
+		}
+	})
+
+	t.Run("should clear the cache", func(t *testing.T) {
+		run(runCacheClear)
+
+		output := run(runCacheStatus)
+		if !strings.Contains(output, "No cached data") {
+			t.Errorf("expected empty cache after clear, got: %s", output)
+		}
+	})
+}
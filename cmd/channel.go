@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
-	"slakctl/internal/config"
-	"slakctl/internal/slack"
+	"github.com/oppai/slakctl/internal/printer"
+	"github.com/oppai/slakctl/internal/timefmt"
+	"github.com/oppai/slakctl/pkg/slack"
 
 	"github.com/spf13/cobra"
 )
@@ -20,26 +23,63 @@ var (
 	showProgress    bool
 	allChannels     bool
 	includeArchived bool
+	channelTypes    string
+	channelOutput   string
+
+	channelMatch        string
+	channelMinMembers   int
+	channelCreatedAfter string
+	channelMemberOf     bool
+	channelShared       bool
+	channelSort         string
+	channelReverse      bool
 )
 
+// channelTypeNames maps the --types values to conversations.list types.
+var channelTypeNames = map[string]string{
+	"public":  "public_channel",
+	"private": "private_channel",
+	"im":      "im",
+	"mpim":    "mpim",
+}
+
 var channelListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List channels",
-	Long:  "List channels in the workspace that the authenticated user has access to.\n\nBy default, this command fetches up to 1000 channels and excludes archived channels. Use --all to fetch all channels and --archived to include archived channels.",
+	Long:  "List channels in the workspace that the authenticated user has access to.\n\nBy default, this command fetches up to 1000 public channels and excludes archived channels. Use --all to fetch all channels, --archived to include archived channels and --types to list private channels, direct messages (im) or group direct messages (mpim).\n\nNarrow the results with --match, --min-members, --created-after, --member-of and --shared, and order them with --sort. Filters apply to the fetched channels, so combine them with --all to search the whole workspace.",
 	RunE:  runChannelList,
 }
 
 func runChannelList(cmd *cobra.Command, args []string) error {
-	cfg, err := config.LoadConfig()
+	types, err := parseChannelTypes(channelTypes)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return err
 	}
 
-	if cfg.Token == "" {
-		return fmt.Errorf("no authentication token found. Please run 'slakctl auth' first")
+	var format printer.Format
+	if channelOutput != "text" {
+		if format, err = printer.ParseFormat(channelOutput); err != nil {
+			return err
+		}
 	}
 
-	client := slack.NewClient(cfg.Token)
+	filter, err := channelFilterFromFlags()
+	if err != nil {
+		return err
+	}
+	if _, err := filter.Matcher(); err != nil {
+		return err
+	}
+
+	sortKey, err := slack.ParseChannelSort(channelSort)
+	if err != nil {
+		return err
+	}
+
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
 
 	var channels []slack.Channel
 	var err2 error
@@ -47,56 +87,206 @@ func runChannelList(cmd *cobra.Command, args []string) error {
 	options := slack.ListChannelsOptions{
 		AllChannels:     allChannels,
 		IncludeArchived: includeArchived,
+		Types:           types,
+		Filter:          filter,
+		Sort:            sortKey,
+		Reverse:         channelReverse,
 	}
 
 	if showProgress {
-		fmt.Println("Fetching channels...")
+		cmd.PrintErrln("Fetching channels...")
 		startTime := time.Now()
 
 		options.ProgressFunc = func(current, total int) {
 			elapsed := time.Since(startTime)
 			if total > 0 {
-				fmt.Printf("\rFetched %d/%d channels (elapsed: %v)", current, total, elapsed.Round(time.Millisecond))
+				cmd.PrintErrf("\rFetched %d/%d channels (elapsed: %v)", current, total, elapsed.Round(time.Millisecond))
 			} else {
-				fmt.Printf("\rFetched %d channels (elapsed: %v)", current, elapsed.Round(time.Millisecond))
+				cmd.PrintErrf("\rFetched %d channels (elapsed: %v)", current, elapsed.Round(time.Millisecond))
 			}
 		}
 
-		channels, err2 = client.ListChannelsWithOptions(options)
+		channels, err2 = client.ListChannelsContext(cmd.Context(), options)
 
-		if err2 == nil {
-			fmt.Println() // 改行
+		if err2 == nil || len(channels) > 0 {
+			cmd.PrintErrln() // 改行
 		}
 	} else {
-		channels, err2 = client.ListChannelsWithOptions(options)
+		channels, err2 = client.ListChannelsContext(cmd.Context(), options)
 	}
 
 	if err2 != nil {
-		return fmt.Errorf("failed to list channels: %w", err2)
+		if !isCancelled(err2) || len(channels) == 0 {
+			return fmt.Errorf("failed to list channels: %w", err2)
+		}
+		// 中断された場合はそれまでに取得できた分を表示する
+		cmd.PrintErrf("Interrupted, showing %d channels fetched so far\n", len(channels))
+		if format.Kind != "" {
+			if err := printer.Print(cmd.OutOrStdout(), format, channels, channelColumns); err != nil {
+				return err
+			}
+			return err2
+		}
+		printChannels(cmd, channels)
+		return err2
+	}
+
+	if format.Kind != "" {
+		return printer.Print(cmd.OutOrStdout(), format, channels, channelColumns)
 	}
 
 	if len(channels) == 0 {
-		fmt.Println("No channels found")
+		cmd.Println("No channels found")
 		return nil
 	}
 
-	fmt.Printf("Found %d channels:\n\n", len(channels))
+	cmd.Printf("Found %d channels:\n\n", len(channels))
+	printChannels(cmd, channels)
+
+	return nil
+}
+
+// channelFilterFromFlags builds the channel filter from the list flags.
+func channelFilterFromFlags() (slack.ChannelFilter, error) {
+	if channelMinMembers < 0 {
+		return slack.ChannelFilter{}, fmt.Errorf("--min-members must not be negative")
+	}
+
+	createdAfter, err := parseTimeFlag(channelCreatedAfter, time.Now())
+	if err != nil {
+		return slack.ChannelFilter{}, fmt.Errorf("invalid --created-after: %w", err)
+	}
+
+	return slack.ChannelFilter{
+		Match:        channelMatch,
+		MinMembers:   channelMinMembers,
+		CreatedAfter: createdAfter,
+		MemberOnly:   channelMemberOf,
+		SharedOnly:   channelShared,
+	}, nil
+}
 
+// channelColumns are the columns of channel list -o table, wide, csv and tsv.
+var channelColumns = []printer.Column[slack.Channel]{
+	{Header: "ID", Value: func(ch slack.Channel) string { return ch.ID }},
+	{Header: "NAME", Value: channelDisplayName},
+	{Header: "TYPE", Value: channelTypeLabel},
+	{Header: "MEMBERS", Value: func(ch slack.Channel) string { return strconv.Itoa(ch.NumMembers) }},
+	{Header: "ARCHIVED", Value: func(ch slack.Channel) string { return strconv.FormatBool(ch.IsArchived) }},
+	{Header: "TOPIC", Wide: true, Value: func(ch slack.Channel) string { return ch.Topic.Value }},
+	{Header: "PURPOSE", Wide: true, Value: func(ch slack.Channel) string { return ch.Purpose.Value }},
+	{Header: "CREATOR", Wide: true, Value: func(ch slack.Channel) string { return ch.Creator }},
+	{Header: "CREATED", Wide: true, Value: func(ch slack.Channel) string {
+		if ch.Created == 0 {
+			return ""
+		}
+		return timefmt.Default.FormatUnix(ch.Created)
+	}},
+	{Header: "SHARED", Wide: true, Value: func(ch slack.Channel) string {
+		switch {
+		case ch.IsExtShared:
+			return "external"
+		case ch.IsShared:
+			return "yes"
+		}
+		return "no"
+	}},
+	{Header: "MEMBER", Wide: true, Value: func(ch slack.Channel) string { return strconv.FormatBool(ch.IsMember) }},
+}
+
+func channelDisplayName(channel slack.Channel) string {
+	if channel.IsIM {
+		return "@" + channel.User
+	}
+	return "#" + channel.Name
+}
+
+func printChannels(cmd *cobra.Command, channels []slack.Channel) {
 	for _, channel := range channels {
-		fmt.Printf("ID: %s\n", channel.ID)
-		fmt.Printf("Name: #%s\n", channel.Name)
+		cmd.Printf("ID: %s\n", channel.ID)
+		cmd.Printf("Name: %s\n", channelDisplayName(channel))
+		cmd.Printf("Type: %s\n", channelTypeLabel(channel))
 		if channel.IsArchived {
-			fmt.Printf("Status: Archived\n")
+			cmd.Printf("Status: Archived\n")
+		}
+		if !channel.IsIM {
+			cmd.Printf("Members: %d\n", channel.NumMembers)
+		}
+		if channel.Topic.Value != "" {
+			cmd.Printf("Topic: %s\n", channel.Topic.Value)
+		}
+		if channel.Purpose.Value != "" {
+			cmd.Printf("Purpose: %s\n", channel.Purpose.Value)
+		}
+		if channel.Creator != "" {
+			cmd.Printf("Creator: %s\n", channel.Creator)
 		}
-		fmt.Println("---")
+		if channel.Created != 0 {
+			cmd.Printf("Created: %s\n", timefmt.Default.FormatUnix(channel.Created))
+		}
+		switch {
+		case channel.IsExtShared:
+			cmd.Printf("Shared: external\n")
+		case channel.IsShared:
+			cmd.Printf("Shared: yes\n")
+		}
+		if channel.IsMember {
+			cmd.Printf("Member: yes\n")
+		}
+		cmd.Println("---")
 	}
+}
 
-	return nil
+func channelTypeLabel(channel slack.Channel) string {
+	switch channel.Type() {
+	case "im":
+		return "direct message"
+	case "mpim":
+		return "group direct message"
+	case "private_channel":
+		return "private channel"
+	}
+	return "public channel"
+}
+
+// parseChannelTypes turns a comma-separated --types value such as
+// "public,private" into conversations.list types.
+func parseChannelTypes(value string) ([]string, error) {
+	var types []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		apiType, ok := channelTypeNames[name]
+		if !ok {
+			// API の型名 (public_channel など) もそのまま受け付ける
+			for _, t := range channelTypeNames {
+				if t == name {
+					apiType, ok = t, true
+				}
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("invalid channel type %q: use public, private, im, or mpim", name)
+		}
+		types = append(types, apiType)
+	}
+	return types, nil
 }
 
 func init() {
 	channelListCmd.Flags().BoolVarP(&showProgress, "progress", "p", true, "Show progress during channel listing")
 	channelListCmd.Flags().BoolVarP(&allChannels, "all", "a", false, "Fetch all channels (default: limit to 1000)")
 	channelListCmd.Flags().BoolVarP(&includeArchived, "archived", "", false, "Include archived channels")
+	channelListCmd.Flags().StringVarP(&channelTypes, "types", "t", "public", "Comma-separated conversation types: public, private, im, mpim")
+	channelListCmd.Flags().StringVarP(&channelOutput, "output", "o", "text", "Output format: text|"+printer.Formats)
+	channelListCmd.Flags().StringVar(&channelMatch, "match", "", "Only show channels whose name matches a glob (inc-*) or /regexp/")
+	channelListCmd.Flags().IntVar(&channelMinMembers, "min-members", 0, "Only show channels with at least this many members")
+	channelListCmd.Flags().StringVar(&channelCreatedAfter, "created-after", "", "Only show channels created after this time (e.g. 30d, 2024-05-01)")
+	channelListCmd.Flags().BoolVar(&channelMemberOf, "member-of", false, "Only show channels you are a member of")
+	channelListCmd.Flags().BoolVar(&channelShared, "shared", false, "Only show channels shared with other workspaces")
+	channelListCmd.Flags().StringVar(&channelSort, "sort", "", "Sort by name, members (largest first) or created (newest first)")
+	channelListCmd.Flags().BoolVar(&channelReverse, "reverse", false, "Reverse the sort order")
 	channelCmd.AddCommand(channelListCmd)
 }
@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"net/http"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -35,4 +38,180 @@ func TestChannelListCmd(t *testing.T) {
 			t.Errorf("expected authentication error, got: %v", err)
 		}
 	})
-}
\ No newline at end of file
+
+	t.Run("should list requested types with details", func(t *testing.T) {
+		newTestWorkspace(t, func(w http.ResponseWriter, r *http.Request) {
+			if types := r.URL.Query().Get("types"); types != "private_channel,im" {
+				t.Errorf("expected types private_channel,im, got: %s", types)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"channels": []map[string]interface{}{
+					{
+						"id": "G1", "name": "secret", "is_private": true, "is_member": true, "is_ext_shared": true,
+						"num_members": 4, "creator": "U1", "created": 1700000000,
+						"topic":   map[string]interface{}{"value": "on-call: alice"},
+						"purpose": map[string]interface{}{"value": "incident response"},
+					},
+					{"id": "D1", "is_im": true, "user": "U2"},
+				},
+			})
+		})
+
+		channelTypes = "private,im"
+		defer func() { channelTypes = "public" }()
+
+		cmd := &cobra.Command{
+			Use:  "list",
+			RunE: runChannelList,
+		}
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&bytes.Buffer{})
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		output := buf.String()
+		for _, expected := range []string{
+			"Name: #secret", "Type: private channel", "Members: 4", "Topic: on-call: alice",
+			"Purpose: incident response", "Creator: U1", "Shared: external", "Member: yes",
+			"Name: @U2", "Type: direct message",
+		} {
+			if !strings.Contains(output, expected) {
+				t.Errorf("expected output to contain %q, got:\n%s", expected, output)
+			}
+		}
+	})
+}
+
+func TestChannelListOutput(t *testing.T) {
+	newTestWorkspace(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"channels": []map[string]interface{}{
+				{"id": "C1", "name": "general", "num_members": 120, "topic": map[string]interface{}{"value": "Company-wide"}},
+				{"id": "C2", "name": "random", "num_members": 3, "is_archived": true},
+			},
+		})
+	})
+
+	defer func() { channelOutput = "text" }()
+
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{"csv", "ID,NAME,TYPE,MEMBERS,ARCHIVED,TOPIC,PURPOSE,CREATOR,CREATED,SHARED,MEMBER\nC1,#general,public channel,120,false,Company-wide,,,,no,false\nC2,#random,public channel,3,true,,,,,no,false\n"},
+		{"table", "ID   NAME       TYPE             MEMBERS   ARCHIVED\nC1   #general   public channel   120       false\nC2   #random    public channel   3         true\n"},
+		{"jsonpath={range [*]}{.name}:{.num_members}{\"\\n\"}{end}", "general:120\nrandom:3\n"},
+	}
+
+	for _, test := range tests {
+		channelOutput = test.output
+
+		cmd := &cobra.Command{
+			Use:  "list",
+			RunE: runChannelList,
+		}
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&bytes.Buffer{})
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("-o %s: expected no error, got: %v", test.output, err)
+		}
+		if buf.String() != test.expected {
+			t.Errorf("-o %s: got %q, expected %q", test.output, buf.String(), test.expected)
+		}
+	}
+
+	t.Run("should filter and sort channels", func(t *testing.T) {
+		channelOutput = "jsonpath={[*].id}"
+		channelMinMembers = 2
+		channelSort = "members"
+		channelReverse = true
+		defer func() {
+			channelMinMembers = 0
+			channelSort = ""
+			channelReverse = false
+		}()
+
+		cmd := &cobra.Command{
+			Use:  "list",
+			RunE: runChannelList,
+		}
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&bytes.Buffer{})
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if buf.String() != "C2 C1\n" {
+			t.Errorf("expected smallest channel first, got: %q", buf.String())
+		}
+	})
+
+	t.Run("should reject invalid filters", func(t *testing.T) {
+		for _, setup := range []func(){
+			func() { channelSort = "size" },
+			func() { channelMatch = "/inc-(/" },
+			func() { channelCreatedAfter = "yesterday" },
+		} {
+			setup()
+
+			cmd := &cobra.Command{
+				Use:  "list",
+				RunE: runChannelList,
+			}
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			if err := cmd.Execute(); err == nil {
+				t.Errorf("expected error for sort=%q match=%q created-after=%q", channelSort, channelMatch, channelCreatedAfter)
+			}
+
+			channelSort, channelMatch, channelCreatedAfter = "", "", ""
+		}
+	})
+
+	t.Run("should reject unknown formats before fetching", func(t *testing.T) {
+		channelOutput = "xml"
+
+		cmd := &cobra.Command{
+			Use:  "list",
+			RunE: runChannelList,
+		}
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "invalid output format") {
+			t.Errorf("expected format error, got: %v", err)
+		}
+	})
+}
+
+func TestParseChannelTypes(t *testing.T) {
+	types, err := parseChannelTypes("public, private,im,mpim")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	expected := []string{"public_channel", "private_channel", "im", "mpim"}
+	if !reflect.DeepEqual(types, expected) {
+		t.Errorf("expected %v, got: %v", expected, types)
+	}
+
+	if types, err := parseChannelTypes("private_channel"); err != nil || !reflect.DeepEqual(types, []string{"private_channel"}) {
+		t.Errorf("expected API type names to be accepted, got: %v (%v)", types, err)
+	}
+
+	if _, err := parseChannelTypes("public,dm"); err == nil || !strings.Contains(err.Error(), "invalid channel type") {
+		t.Errorf("expected error for unknown type, got: %v", err)
+	}
+}
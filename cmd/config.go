@@ -3,8 +3,8 @@ package cmd
 import (
 	"fmt"
 
-	"slakctl/internal/auth"
-	"slakctl/internal/config"
+	"github.com/oppai/slakctl/internal/auth"
+	"github.com/oppai/slakctl/internal/config"
 
 	"github.com/spf13/cobra"
 )
@@ -81,6 +81,10 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 		cmd.Println("Token: Not set")
 	}
 
+	if cfg.AppToken != "" {
+		cmd.Printf("App Token: %s\n", maskSecret(cfg.AppToken))
+	}
+
 	return nil
 }
 
@@ -114,6 +118,10 @@ func runAuthOAuth(cmd *cobra.Command, args []string) error {
 	}
 
 	cfg.Token = token
+	cfg.TeamID = ""
+	if identity, err := newClient(token).AuthTestContext(cmd.Context()); err == nil {
+		cfg.TeamID = identity.TeamID
+	}
 
 	if err := config.SaveConfig(cfg); err != nil {
 		return fmt.Errorf("failed to save token: %w", err)
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/oppai/slakctl/internal/printer"
+
+	"github.com/spf13/cobra"
+)
+
+var emojiOutput string
+
+// customEmoji is one row of emoji list.
+type customEmoji struct {
+	Name    string `json:"name"`
+	URL     string `json:"url,omitempty"`
+	AliasOf string `json:"alias_of,omitempty"`
+}
+
+var emojiCmd = &cobra.Command{
+	Use:   "emoji",
+	Short: "Custom emoji commands",
+}
+
+var emojiListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the custom emoji of the workspace",
+	Long:  "List the custom emoji of the workspace with their image URLs. Aliases show the emoji they point to. The list is cached like channels and users; use --refresh to fetch it again.",
+	Args:  cobra.NoArgs,
+	RunE:  runEmojiList,
+}
+
+func init() {
+	emojiListCmd.Flags().StringVarP(&emojiOutput, "output", "o", "table", "Output format: "+printer.Formats)
+	emojiCmd.AddCommand(emojiListCmd)
+}
+
+func runEmojiList(cmd *cobra.Command, args []string) error {
+	format, err := printer.ParseFormat(emojiOutput)
+	if err != nil {
+		return err
+	}
+
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := client.ListEmojiContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	emoji := make([]customEmoji, 0, len(list))
+	for name, value := range list {
+		if alias, ok := strings.CutPrefix(value, "alias:"); ok {
+			emoji = append(emoji, customEmoji{Name: name, AliasOf: alias})
+		} else {
+			emoji = append(emoji, customEmoji{Name: name, URL: value})
+		}
+	}
+	sort.Slice(emoji, func(i, j int) bool { return emoji[i].Name < emoji[j].Name })
+
+	return printer.Print(cmd.OutOrStdout(), format, emoji, emojiColumns)
+}
+
+// emojiColumns are the columns of emoji list -o table, wide, csv and tsv.
+var emojiColumns = []printer.Column[customEmoji]{
+	{Header: "NAME", Value: func(e customEmoji) string { return ":" + e.Name + ":" }},
+	{Header: "ALIAS OF", Value: func(e customEmoji) string {
+		if e.AliasOf == "" {
+			return ""
+		}
+		return ":" + e.AliasOf + ":"
+	}},
+	{Header: "URL", Value: func(e customEmoji) string { return e.URL }},
+}
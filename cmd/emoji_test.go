@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestEmojiListCmd(t *testing.T) {
+	requests := 0
+	newTestWorkspace(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/emoji.list" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		requests++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"emoji": map[string]string{
+				"shipit":   "https://emoji.slack-edge.com/T1/shipit/abc.png",
+				"squirrel": "alias:shipit",
+			},
+		})
+	})
+
+	output, err := runManageCmd(runEmojiList, "")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], ":shipit:") || !strings.Contains(lines[1], "https://emoji.slack-edge.com/T1/shipit/abc.png") {
+		t.Errorf("unexpected output: %s", output)
+	}
+	if !strings.HasPrefix(lines[2], ":squirrel:") || !strings.Contains(lines[2], ":shipit:") {
+		t.Errorf("expected alias to show its target, got: %s", output)
+	}
+
+	if _, err := runManageCmd(runEmojiList, ""); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected second listing to be served from cache, got %d requests", requests)
+	}
+}
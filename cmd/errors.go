@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/oppai/slakctl/pkg/slack"
+)
+
+// Exit codes returned by slakctl. Scripts can rely on these to tell apart
+// failures that need different handling.
+const (
+	ExitOK          = 0
+	ExitError       = 1
+	ExitAuth        = 3
+	ExitPermission  = 4
+	ExitNotFound    = 5
+	ExitRateLimited = 6
+	ExitInterrupted = 130
+)
+
+var errNoToken = errors.New("no authentication token found. Please run 'slakctl auth' first")
+
+var (
+	authErrorCodes = []string{
+		"not_authed", "invalid_auth", "account_inactive", "token_revoked", "token_expired",
+	}
+	permissionErrorCodes = []string{
+		"missing_scope", "not_allowed_token_type", "no_permission", "restricted_action",
+		"not_in_channel", "ekm_access_denied", "access_denied",
+	}
+	notFoundErrorCodes = []string{
+		"channel_not_found", "user_not_found", "users_not_found", "thread_not_found", "message_not_found",
+	}
+)
+
+// ExitCode maps an error returned by Execute to the process exit code.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	if isCancelled(err) {
+		return ExitInterrupted
+	}
+
+	if errors.Is(err, errNoToken) {
+		return ExitAuth
+	}
+
+	var notFound *slack.NotFoundError
+	if errors.As(err, &notFound) {
+		return ExitNotFound
+	}
+
+	var apiErr *slack.APIError
+	if !errors.As(err, &apiErr) {
+		return ExitError
+	}
+
+	switch {
+	case apiErr.IsRateLimited():
+		return ExitRateLimited
+	case slack.IsErrorCode(err, authErrorCodes...):
+		return ExitAuth
+	case slack.IsErrorCode(err, permissionErrorCodes...):
+		return ExitPermission
+	case slack.IsErrorCode(err, notFoundErrorCodes...):
+		return ExitNotFound
+	}
+
+	return ExitError
+}
+
+// Hint returns an actionable suggestion for err, or "" if there is none.
+func Hint(err error) string {
+	var notFound *slack.NotFoundError
+	if errors.As(err, &notFound) {
+		if len(notFound.Suggestions) == 0 {
+			return ""
+		}
+		return fmt.Sprintf("did you mean %s?", strings.Join(notFound.Suggestions, ", "))
+	}
+
+	var apiErr *slack.APIError
+	if !errors.As(err, &apiErr) {
+		return ""
+	}
+
+	if apiErr.IsRateLimited() {
+		return "Slack is rate limiting requests; wait a minute and try again"
+	}
+
+	switch apiErr.Code {
+	case "missing_scope":
+		if apiErr.Needed != "" {
+			return fmt.Sprintf("add the %s scope to your Slack app under \"OAuth & Permissions\" and reinstall it", apiErr.Needed)
+		}
+		return "add the missing scope to your Slack app under \"OAuth & Permissions\" and reinstall it"
+	case "not_authed", "invalid_auth", "token_revoked", "token_expired", "account_inactive":
+		return "run 'slakctl auth token' or 'slakctl config oauth' to authenticate again"
+	case "not_allowed_token_type":
+		switch apiErr.Method {
+		case "search.messages", "search.files", "search.all":
+			return fmt.Sprintf("%s cannot be called with this kind of token; search requires a user token (xoxp-)", apiErr.Method)
+		case "apps.connections.open":
+			return fmt.Sprintf("%s requires an app-level token (xapp-); set it with 'slakctl auth app-token'", apiErr.Method)
+		}
+		return fmt.Sprintf("%s cannot be called with this kind of token; check whether it needs a bot (xoxb-), user (xoxp-) or app-level (xapp-) token", apiErr.Method)
+	case "not_in_channel":
+		return "invite the app to the channel with /invite, or join it first"
+	case "channel_not_found":
+		return "check the channel name or ID; private channels are only visible once the app is a member"
+	case "is_archived":
+		return "the channel is archived; unarchive it first"
+	case "name_taken":
+		return "a channel with that name already exists; archived channels keep their names, so check 'slakctl channel list --archived'"
+	case "cant_archive_general":
+		return "the workspace's default channel cannot be archived"
+	case "cant_kick_self":
+		return "use 'slakctl channel leave' to leave a channel yourself"
+	case "method_not_supported_for_channel_type":
+		return "private channels cannot be joined; ask a member to invite you"
+	case "restricted_action":
+		return "workspace settings do not allow this action for your account; ask a workspace admin"
+	}
+
+	return ""
+}
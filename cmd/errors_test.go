@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/oppai/slakctl/pkg/slack"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"nil", nil, ExitOK},
+		{"plain error", errors.New("boom"), ExitError},
+		{"no token", errNoToken, ExitAuth},
+		{"cancelled", fmt.Errorf("search failed: %w", context.Canceled), ExitInterrupted},
+		{"invalid auth", &slack.APIError{Method: "auth.test", Code: "invalid_auth"}, ExitAuth},
+		{"missing scope", fmt.Errorf("wrapped: %w", &slack.APIError{Code: "missing_scope"}), ExitPermission},
+		{"channel not found", &slack.APIError{Code: "channel_not_found"}, ExitNotFound},
+		{"rate limited", &slack.APIError{StatusCode: http.StatusTooManyRequests, Code: "ratelimited"}, ExitRateLimited},
+		{"unknown code", &slack.APIError{Code: "fatal_error"}, ExitError},
+		{"unresolved name", &slack.NotFoundError{Kind: "channel", Query: "#genral"}, ExitNotFound},
+	}
+
+	for _, test := range tests {
+		if code := ExitCode(test.err); code != test.expected {
+			t.Errorf("%s: ExitCode() = %d, expected %d", test.name, code, test.expected)
+		}
+	}
+}
+
+func TestHint(t *testing.T) {
+	t.Run("should suggest needed scope", func(t *testing.T) {
+		hint := Hint(&slack.APIError{Method: "chat.postMessage", Code: "missing_scope", Needed: "chat:write"})
+		if !strings.Contains(hint, "chat:write") || !strings.Contains(hint, "reinstall") {
+			t.Errorf("expected hint to mention chat:write and reinstall, got: %s", hint)
+		}
+	})
+
+	t.Run("should only mention user tokens for search", func(t *testing.T) {
+		hint := Hint(&slack.APIError{Method: "search.messages", Code: "not_allowed_token_type"})
+		if !strings.Contains(hint, "search requires a user token") {
+			t.Errorf("expected user token hint, got: %s", hint)
+		}
+
+		hint = Hint(&slack.APIError{Method: "apps.connections.open", Code: "not_allowed_token_type"})
+		if strings.Contains(hint, "search") || !strings.Contains(hint, "xapp-") {
+			t.Errorf("expected app-level token hint, got: %s", hint)
+		}
+
+		hint = Hint(&slack.APIError{Method: "conversations.history", Code: "not_allowed_token_type"})
+		if strings.Contains(hint, "search") {
+			t.Errorf("expected generic token hint, got: %s", hint)
+		}
+	})
+
+	t.Run("should suggest similar names", func(t *testing.T) {
+		hint := Hint(fmt.Errorf("wrapped: %w", &slack.NotFoundError{Kind: "channel", Query: "#genral", Suggestions: []string{"#general", "#generic"}}))
+		if hint != "did you mean #general, #generic?" {
+			t.Errorf("unexpected hint: %s", hint)
+		}
+	})
+
+	t.Run("should return empty hint for plain errors", func(t *testing.T) {
+		if hint := Hint(errors.New("boom")); hint != "" {
+			t.Errorf("expected empty hint, got: %s", hint)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/oppai/slakctl/internal/export"
+	"github.com/oppai/slakctl/pkg/slack"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportOutput   string
+	exportFormat   string
+	exportFiles    bool
+	exportProgress bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export <channel...>",
+	Short: "Archive channels to JSON, Markdown or HTML",
+	Long:  "Export the full history of one or more channels, including thread replies, into a directory.\n\nMessages are always written as Slack-export-compatible JSON, one file per channel and day. With --format markdown or html a transcript is rendered next to it. Running the command again on the same directory resumes an interrupted export and only fetches messages posted since the last run. Replies posted since to threads that were already exported are not picked up; export to a new directory to include them.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "slakctl-export", "Directory to write the archive to")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "json", "Archive format: json, markdown, or html")
+	exportCmd.Flags().BoolVar(&exportFiles, "files", false, "Download attached files")
+	exportCmd.Flags().BoolVarP(&exportProgress, "progress", "p", false, "Show progress while exporting")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	format, err := export.ParseFormat(exportFormat)
+	if err != nil {
+		return err
+	}
+
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	channels, err := resolveChannels(cmd, client, args)
+	if err != nil {
+		return err
+	}
+
+	options := export.Options{
+		Dir:           exportOutput,
+		Format:        format,
+		DownloadFiles: exportFiles,
+		Warn: func(format string, args ...interface{}) {
+			cmd.PrintErrf("Warning: "+format+"\n", args...)
+		},
+	}
+	if exportProgress {
+		options.Progress = func(channel string, messages int) {
+			cmd.PrintErrf("#%s: exported %d messages\n", channel, messages)
+		}
+	}
+
+	if err := export.New(client, options).Export(cmd.Context(), channels); err != nil {
+		if isCancelled(err) {
+			// 状態ファイルは保存済みなので同じコマンドで再開できる
+			cmd.PrintErrln("Interrupted, run the same command again to resume")
+		}
+		return fmt.Errorf("failed to export: %w", err)
+	}
+
+	cmd.Printf("Exported %d channels to %s\n", len(channels), exportOutput)
+	return nil
+}
+
+// resolveChannels looks up every channel argument so the export knows
+// both the ID and the name.
+func resolveChannels(cmd *cobra.Command, client *slack.Client, args []string) ([]slack.Channel, error) {
+	resolver := slack.NewResolver(client)
+
+	channels := make([]slack.Channel, 0, len(args))
+	for _, arg := range args {
+		ch, err := resolver.ResolveChannel(cmd.Context(), arg)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, *ch)
+	}
+	return channels, nil
+}
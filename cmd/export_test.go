@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestExportCmd(t *testing.T) {
+	t.Run("should reject unsupported formats", func(t *testing.T) {
+		exportFormat = "pdf"
+		defer func() { exportFormat = "json" }()
+
+		cmd := &cobra.Command{
+			Use:  "export",
+			RunE: runExport,
+		}
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"general"})
+
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "invalid export format") {
+			t.Errorf("expected format error, got: %v", err)
+		}
+	})
+
+	t.Run("should export channels by name", func(t *testing.T) {
+		tempDir := newTestWorkspace(t, func(w http.ResponseWriter, r *http.Request) {
+			var response map[string]interface{}
+			switch r.URL.Path {
+			case "/api/conversations.list":
+				response = map[string]interface{}{
+					"ok": true,
+					"channels": []map[string]interface{}{
+						{"id": "C1", "name": "general"},
+						{"id": "C2", "name": "random"},
+					},
+				}
+			case "/api/conversations.history":
+				if r.URL.Query().Get("channel") != "C1" {
+					t.Errorf("expected channel C1, got: %s", r.URL.Query().Get("channel"))
+				}
+				response = map[string]interface{}{
+					"ok": true,
+					"messages": []map[string]interface{}{
+						{"user": "U1", "text": "hello", "ts": "1700000000.000000"},
+					},
+				}
+			case "/api/users.list":
+				response = map[string]interface{}{
+					"ok":      true,
+					"members": []map[string]interface{}{{"id": "U1", "name": "alice"}},
+				}
+			default:
+				t.Errorf("unexpected path: %s", r.URL.Path)
+			}
+			json.NewEncoder(w).Encode(response)
+		})
+
+		exportOutput = filepath.Join(tempDir, "archive")
+		exportFormat = "markdown"
+		defer func() {
+			exportOutput = "slakctl-export"
+			exportFormat = "json"
+		}()
+
+		cmd := &cobra.Command{
+			Use:  "export",
+			RunE: runExport,
+		}
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"#general"})
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if !strings.Contains(buf.String(), "Exported 1 channels") {
+			t.Errorf("expected summary, got: %s", buf.String())
+		}
+		if _, err := os.Stat(filepath.Join(exportOutput, "general", "2023-11-14.json")); err != nil {
+			t.Errorf("expected day file: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(exportOutput, "general.md")); err != nil {
+			t.Errorf("expected markdown transcript: %v", err)
+		}
+	})
+
+	t.Run("should fail for unknown channels", func(t *testing.T) {
+		newTestWorkspace(t, func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "channels": []map[string]interface{}{}})
+		})
+
+		cmd := &cobra.Command{
+			Use:  "export",
+			RunE: runExport,
+		}
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"missing"})
+
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "channel not found: missing") {
+			t.Errorf("expected not found error, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/oppai/slakctl/internal/config"
+)
+
+// newTestWorkspace は handler を Slack API として立ち上げ、設定・キャッシュ・API URL を
+// 一時ディレクトリとそのサーバーに向ける
+func newTestWorkspace(t *testing.T, handler http.HandlerFunc) string {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+	t.Setenv("XDG_CACHE_HOME", tempDir)
+	t.Setenv(APIURLEnv, server.URL+"/api")
+
+	if err := config.SaveConfig(&config.Config{Token: "test-token"}); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	return tempDir
+}
+
+// slackMethod は API メソッドへの応答を作る。"ok" を含まない応答は成功として返す
+type slackMethod func(r *http.Request) map[string]interface{}
+
+// slackMethods は API メソッド名ごとの応答
+type slackMethods map[string]slackMethod
+
+// respond は常に response を返す slackMethod
+func respond(response map[string]interface{}) slackMethod {
+	return func(*http.Request) map[string]interface{} { return response }
+}
+
+// newFakeSlack は methods を応答する Slack API で newTestWorkspace を立ち上げ、
+// 呼ばれた書き込み系 (POST) メソッドの "メソッド名 本文" の記録と一時ディレクトリを返す。
+// methods にないメソッドは {"ok": true} を返す
+func newFakeSlack(t *testing.T, methods slackMethods) (*[]string, string) {
+	t.Helper()
+
+	// 並行に呼ばれても methods が記録を書き換えられるよう、リクエストを一つずつ処理する
+	var mu sync.Mutex
+	var calls []string
+	tempDir := newTestWorkspace(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		method := strings.TrimPrefix(r.URL.Path, "/api/")
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			calls = append(calls, method+" "+string(body))
+		}
+
+		response := map[string]interface{}{"ok": true}
+		if respond, ok := methods[method]; ok {
+			response = respond(r)
+			if _, ok := response["ok"]; !ok {
+				response["ok"] = true
+			}
+		}
+		json.NewEncoder(w).Encode(response)
+	})
+
+	return &calls, tempDir
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/oppai/slakctl/internal/printer"
+	"github.com/oppai/slakctl/pkg/slack"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	historySince       string
+	historyUntil       string
+	historyLimit       int
+	historyWithReplies bool
+	historyFormat      string
+	historyProgress    bool
+)
+
+var channelHistoryCmd = &cobra.Command{
+	Use:   "history [channel]",
+	Short: "Show messages of a channel",
+	Long:  "Show messages posted to a channel, newest first.\n\nUse --since and --until to select a time window, either relative to now (30m, 2h, 3d, 1w) or as an absolute date (2024-05-01, 2024-05-01 09:00). Use --with-replies to show thread replies under their parent message.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runChannelHistory,
+}
+
+func init() {
+	channelHistoryCmd.Flags().StringVar(&historySince, "since", "", "Only show messages after this time (e.g. 2h, 3d, 2024-05-01)")
+	channelHistoryCmd.Flags().StringVar(&historyUntil, "until", "", "Only show messages before this time (e.g. 1h, 2024-05-02)")
+	channelHistoryCmd.Flags().IntVarP(&historyLimit, "limit", "l", 100, "Maximum number of messages to show (0 for no limit)")
+	channelHistoryCmd.Flags().BoolVarP(&historyWithReplies, "with-replies", "r", false, "Show thread replies under their parent message")
+	channelHistoryCmd.Flags().StringVarP(&historyFormat, "format", "f", "text", "Output format: text, json, or a custom format such as '{{.channel.name}}: {{.text}}'")
+	channelHistoryCmd.Flags().BoolVarP(&historyProgress, "progress", "p", false, "Show progress while fetching messages")
+	channelCmd.AddCommand(channelHistoryCmd)
+}
+
+// historyEntry is a message with its thread replies inlined.
+type historyEntry struct {
+	slack.Message
+	Replies []slack.Message `json:"replies,omitempty"`
+}
+
+func runChannelHistory(cmd *cobra.Command, args []string) error {
+	if historyLimit < 0 {
+		return fmt.Errorf("limit must not be negative")
+	}
+	if err := validateFormat(historyFormat, messagePlaceholders); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	oldest, err := parseTimeFlag(historySince, now)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	latest, err := parseTimeFlag(historyUntil, now)
+	if err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+	if !oldest.IsZero() && !latest.IsZero() && !oldest.Before(latest) {
+		return fmt.Errorf("--since must be before --until")
+	}
+
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+
+	channel, err := resolveChannelRef(cmd, slack.NewResolver(client), args[0])
+	if err != nil {
+		return err
+	}
+	channelID := channel.ID
+
+	options := slack.HistoryOptions{
+		Oldest: oldest,
+		Latest: latest,
+		Limit:  historyLimit,
+	}
+	if historyProgress {
+		options.ProgressFunc = func(current, total int) {
+			cmd.PrintErrf("\rFetched %d messages", current)
+		}
+	}
+
+	messages, fetchErr := client.HistoryContext(ctx, channelID, options)
+	if historyProgress && len(messages) > 0 {
+		cmd.PrintErrln()
+	}
+	if fetchErr != nil && (!isCancelled(fetchErr) || len(messages) == 0) {
+		return fmt.Errorf("failed to fetch history: %w", fetchErr)
+	}
+
+	entries := make([]historyEntry, 0, len(messages))
+	for _, msg := range messages {
+		entry := historyEntry{Message: msg}
+		if historyWithReplies && fetchErr == nil && msg.IsThreadParent() {
+			replies, err := client.RepliesContext(ctx, channelID, msg.TS)
+			if err != nil {
+				if !isCancelled(err) {
+					return fmt.Errorf("failed to fetch replies: %w", err)
+				}
+				fetchErr = err
+			}
+			entry.Replies = replies
+		}
+		entries = append(entries, entry)
+	}
+
+	if fetchErr != nil {
+		// 中断された場合はそれまでに取得できた分を表示する
+		cmd.PrintErrf("Interrupted, showing %d messages fetched so far\n", len(entries))
+	}
+
+	names := map[string]string{}
+	if fetchErr == nil {
+		users, err := client.ListUsersContext(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			// 投稿者名は表示のためだけなので ID のまま続ける
+			cmd.PrintErrf("Warning: could not list users, authors are shown by ID: %v\n", err)
+		}
+		for _, user := range users {
+			names[user.ID] = user.Name
+		}
+	}
+	label := func(msg *slack.Message) {
+		msg.Channel = channel
+		if msg.Username == "" && names[msg.User] != "" {
+			msg.Username = names[msg.User]
+		}
+	}
+	for i := range entries {
+		label(&entries[i].Message)
+		for j := range entries[i].Replies {
+			label(&entries[i].Replies[j])
+		}
+	}
+
+	if err := printHistory(cmd, channelLabel(channel), entries, historyFormat); err != nil {
+		return err
+	}
+	return fetchErr
+}
+
+func printHistory(cmd *cobra.Command, channel string, entries []historyEntry, format string) error {
+	switch format {
+	case "json":
+		output := map[string]interface{}{
+			"channel":  channel,
+			"messages": entries,
+			"total":    len(entries),
+		}
+		jsonOutput, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		cmd.Println(string(jsonOutput))
+
+	case "text":
+		if len(entries) == 0 {
+			cmd.Printf("No messages found in %s\n", channel)
+			return nil
+		}
+
+		cmd.Printf("Found %d messages in %s:\n\n", len(entries), channel)
+		for _, entry := range entries {
+			printMessageText(cmd, entry.Message, "")
+			for _, reply := range entry.Replies {
+				cmd.Println("  ↳")
+				printMessageText(cmd, reply, "    ")
+			}
+			cmd.Println("---")
+		}
+
+	default:
+		tmpl, err := printer.ParseTemplate(format, messagePlaceholders)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			output, err := tmpl.Execute(entry.Message)
+			if err != nil {
+				return err
+			}
+			cmd.Println(output)
+			for _, reply := range entry.Replies {
+				if output, err = tmpl.Execute(reply); err != nil {
+					return err
+				}
+				cmd.Println("  " + output)
+			}
+		}
+	}
+
+	return nil
+}
+
+// channelLabel names a conversation in headers: #channel, or @user for
+// direct messages.
+func channelLabel(ref slack.ChannelRef) string {
+	if ref.IsIM {
+		return "@" + ref.Name
+	}
+	return "#" + ref.Name
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestParseTimeFlag(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"", time.Time{}},
+		{"2h", now.Add(-2 * time.Hour)},
+		{"30m", now.Add(-30 * time.Minute)},
+		{"3d", now.Add(-3 * 24 * time.Hour)},
+		{"1w", now.Add(-7 * 24 * time.Hour)},
+		{"2024-05-01", time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)},
+		{"2024-05-01 09:30", time.Date(2024, 5, 1, 9, 30, 0, 0, time.Local)},
+		{"2024-05-01T09:30:00Z", time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		got, err := parseTimeFlag(test.input, now)
+		if err != nil {
+			t.Errorf("parseTimeFlag(%s) returned error: %v", test.input, err)
+			continue
+		}
+		if !got.Equal(test.expected) {
+			t.Errorf("parseTimeFlag(%s) = %v, expected %v", test.input, got, test.expected)
+		}
+	}
+
+	for _, input := range []string{"yesterday", "-2h", "2024-13-01"} {
+		if _, err := parseTimeFlag(input, now); err == nil {
+			t.Errorf("expected error for %s", input)
+		}
+	}
+}
+
+func TestChannelHistoryCmd(t *testing.T) {
+	t.Run("should require authentication", func(t *testing.T) {
+		tempDir, err := os.MkdirTemp("", "slakctl-test-*")
+		if err != nil {
+			t.Fatalf("failed to create temp dir: %v", err)
+		}
+		defer os.RemoveAll(tempDir)
+
+		originalHome := os.Getenv("HOME")
+		os.Setenv("HOME", tempDir)
+		defer os.Setenv("HOME", originalHome)
+
+		cmd := &cobra.Command{
+			Use:  "history",
+			RunE: runChannelHistory,
+		}
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"C1234567890"})
+
+		err = cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "no authentication token found") {
+			t.Errorf("expected authentication error, got: %v", err)
+		}
+	})
+
+	t.Run("should print messages with replies", func(t *testing.T) {
+		newTestWorkspace(t, func(w http.ResponseWriter, r *http.Request) {
+			var response map[string]interface{}
+			switch r.URL.Path {
+			case "/api/conversations.history":
+				response = map[string]interface{}{
+					"ok": true,
+					"messages": []map[string]interface{}{
+						{"user": "U1", "text": "deploy started", "ts": "1700000002.000000", "thread_ts": "1700000002.000000", "reply_count": 1},
+					},
+				}
+			case "/api/conversations.replies":
+				response = map[string]interface{}{
+					"ok": true,
+					"messages": []map[string]interface{}{
+						{"user": "U1", "text": "deploy started", "ts": "1700000002.000000"},
+						{"user": "U2", "text": "looks good", "ts": "1700000003.000000"},
+					},
+				}
+			case "/api/conversations.list":
+				response = map[string]interface{}{"ok": true, "channels": []map[string]interface{}{{"id": "C1234567890", "name": "deploys"}}}
+			case "/api/users.list":
+				response = map[string]interface{}{
+					"ok":      true,
+					"members": []map[string]interface{}{{"id": "U1", "name": "alice"}, {"id": "U2", "name": "bob"}},
+				}
+			default:
+				t.Errorf("unexpected path: %s", r.URL.Path)
+			}
+			json.NewEncoder(w).Encode(response)
+		})
+
+		historyWithReplies = true
+		defer func() { historyWithReplies = false }()
+
+		cmd := &cobra.Command{
+			Use:  "history",
+			RunE: runChannelHistory,
+		}
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"C1234567890"})
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		output := buf.String()
+		if !strings.Contains(output, "deploy started") || !strings.Contains(output, "looks good") {
+			t.Errorf("expected message and reply in output, got: %s", output)
+		}
+		if !strings.Contains(output, "Found 1 messages in #deploys") || !strings.Contains(output, "Channel: #deploys") {
+			t.Errorf("expected channel name in output, got: %s", output)
+		}
+		if !strings.Contains(output, "User: alice") || !strings.Contains(output, "User: bob") {
+			t.Errorf("expected author names in output, got: %s", output)
+		}
+	})
+
+	t.Run("should show authors by ID when users cannot be listed", func(t *testing.T) {
+		newTestWorkspace(t, func(w http.ResponseWriter, r *http.Request) {
+			var response map[string]interface{}
+			switch r.URL.Path {
+			case "/api/conversations.history":
+				response = map[string]interface{}{
+					"ok":       true,
+					"messages": []map[string]interface{}{{"user": "U1", "text": "deploy started", "ts": "1700000002.000000"}},
+				}
+			case "/api/conversations.list":
+				response = map[string]interface{}{"ok": true, "channels": []map[string]interface{}{{"id": "C1234567890", "name": "deploys"}}}
+			case "/api/users.list":
+				response = map[string]interface{}{"ok": false, "error": "missing_scope", "needed": "users:read"}
+			default:
+				t.Errorf("unexpected path: %s", r.URL.Path)
+			}
+			json.NewEncoder(w).Encode(response)
+		})
+
+		historyFormat = "{{.channel.name}} {{or .username .user}}: {{.text}}"
+		defer func() { historyFormat = "text" }()
+
+		output, err := runManageCmd(runChannelHistory, "", "C1234567890")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, "Warning: could not list users") || !strings.Contains(output, "deploys U1: deploy started") {
+			t.Errorf("unexpected output: %s", output)
+		}
+	})
+}
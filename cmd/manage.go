@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/oppai/slakctl/pkg/slack"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	dryRun        bool
+	assumeYes     bool
+	createPrivate bool
+)
+
+var errNotConfirmed = errors.New("aborted")
+
+var channelCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a channel",
+	Long:  "Create a public channel, or a private one with --private. Channel names may contain up to 80 lowercase letters, numbers, hyphens and underscores.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runChannelCreate,
+}
+
+var channelArchiveCmd = &cobra.Command{
+	Use:   "archive <channel>",
+	Short: "Archive a channel",
+	Long:  "Archive a channel. You are asked for confirmation unless --yes is given; use --dry-run to see what would be archived.\n\nWith --from-file, archive every channel listed in a file (or standard input with -). Each channel is reported as it is processed and the results are written to a CSV file; pass that file to --from-file again to retry only the failures.",
+	RunE:  runChannelArchive,
+}
+
+var channelUnarchiveCmd = &cobra.Command{
+	Use:   "unarchive <channel>",
+	Short: "Unarchive a channel",
+	Long:  "Unarchive a channel, or every channel listed in a file with --from-file.",
+	RunE:  runChannelUnarchive,
+}
+
+var channelRenameCmd = &cobra.Command{
+	Use:   "rename <channel> <new-name>",
+	Short: "Rename a channel",
+	Long:  "Rename a channel. Links and mentions of the old name stop working, so you are asked for confirmation unless --yes is given.",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runChannelRename,
+}
+
+func init() {
+	channelCreateCmd.Flags().BoolVar(&createPrivate, "private", false, "Create a private channel")
+
+	for _, c := range []*cobra.Command{channelCreateCmd, channelArchiveCmd, channelUnarchiveCmd, channelRenameCmd} {
+		c.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without changing anything")
+		channelCmd.AddCommand(c)
+	}
+	for _, c := range []*cobra.Command{channelArchiveCmd, channelUnarchiveCmd, channelRenameCmd} {
+		c.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Do not ask for confirmation")
+	}
+	for _, c := range []*cobra.Command{channelArchiveCmd, channelUnarchiveCmd} {
+		addBatchFlags(c)
+	}
+}
+
+func runChannelCreate(cmd *cobra.Command, args []string) error {
+	name := strings.TrimPrefix(args[0], "#")
+	if err := slack.ValidateChannelName(name); err != nil {
+		return err
+	}
+
+	kind := "public"
+	if createPrivate {
+		kind = "private"
+	}
+
+	if dryRun {
+		cmd.Printf("Would create %s channel #%s\n", kind, name)
+		return nil
+	}
+
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	channel, err := client.CreateChannel(cmd.Context(), name, createPrivate)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Created %s channel #%s (%s)\n", kind, channel.Name, channel.ID)
+	return nil
+}
+
+func runChannelArchive(cmd *cobra.Command, args []string) error {
+	if batchFile != "" {
+		return runBatch(cmd, archiveOperation)
+	}
+
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	channel, err := lookupChannel(cmd, client, args[0])
+	if err != nil {
+		return err
+	}
+	if channel.IsArchived {
+		return fmt.Errorf("#%s is already archived", channel.Name)
+	}
+
+	if dryRun {
+		cmd.Printf("Would archive #%s (%s, %d members)\n", channel.Name, channel.ID, channel.NumMembers)
+		return nil
+	}
+
+	if err := confirm(cmd, fmt.Sprintf("Archive #%s (%s, %d members)?", channel.Name, channel.ID, channel.NumMembers)); err != nil {
+		return err
+	}
+
+	if err := client.ArchiveChannel(cmd.Context(), channel.ID); err != nil {
+		return err
+	}
+
+	cmd.Printf("Archived #%s\n", channel.Name)
+	return nil
+}
+
+func runChannelUnarchive(cmd *cobra.Command, args []string) error {
+	if batchFile != "" {
+		return runBatch(cmd, unarchiveOperation)
+	}
+
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	channel, err := lookupChannel(cmd, client, args[0])
+	if err != nil {
+		return err
+	}
+	if !channel.IsArchived {
+		return fmt.Errorf("#%s is not archived", channel.Name)
+	}
+
+	if dryRun {
+		cmd.Printf("Would unarchive #%s (%s)\n", channel.Name, channel.ID)
+		return nil
+	}
+
+	if err := client.UnarchiveChannel(cmd.Context(), channel.ID); err != nil {
+		return err
+	}
+
+	cmd.Printf("Unarchived #%s\n", channel.Name)
+	return nil
+}
+
+func runChannelRename(cmd *cobra.Command, args []string) error {
+	name := strings.TrimPrefix(args[1], "#")
+	if err := slack.ValidateChannelName(name); err != nil {
+		return err
+	}
+
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	channel, err := lookupChannel(cmd, client, args[0])
+	if err != nil {
+		return err
+	}
+	if channel.Name == name {
+		return fmt.Errorf("#%s already has that name", channel.Name)
+	}
+
+	if dryRun {
+		cmd.Printf("Would rename #%s (%s) to #%s\n", channel.Name, channel.ID, name)
+		return nil
+	}
+
+	if err := confirm(cmd, fmt.Sprintf("Rename #%s (%s) to #%s?", channel.Name, channel.ID, name)); err != nil {
+		return err
+	}
+
+	renamed, err := client.RenameChannel(cmd.Context(), channel.ID, name)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Renamed #%s to #%s\n", channel.Name, renamed.Name)
+	return nil
+}
+
+// lookupChannel resolves ref and fetches the channel's current state, so
+// that decisions are not based on a cached channel list.
+func lookupChannel(cmd *cobra.Command, client *slack.Client, ref string) (*slack.Channel, error) {
+	channelID, err := slack.NewResolver(client).ResolveChannelID(cmd.Context(), ref)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetConversationInfo(cmd.Context(), channelID)
+}
+
+// requireYesForStdin rejects reading input from standard input ("-")
+// without --yes: once the input is read there is nothing left to answer
+// the confirmation prompt.
+func requireYesForStdin(path string) error {
+	if path == "-" && !assumeYes {
+		return fmt.Errorf("--yes is required when reading from standard input, as the confirmation prompt cannot be answered")
+	}
+	return nil
+}
+
+// confirm asks prompt on stderr and reads the answer from stdin. It
+// returns nil only for "y" or "yes", or when --yes was given.
+func confirm(cmd *cobra.Command, prompt string) error {
+	if assumeYes {
+		return nil
+	}
+
+	cmd.PrintErrf("%s [y/N]: ", prompt)
+
+	answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if errors.Is(err, io.EOF) && answer == "" {
+		// 標準入力が閉じている（パイプや CI）場合は確認できないので中止する
+		cmd.PrintErrln()
+		return fmt.Errorf("confirmation required; pass --yes to run non-interactively")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return errNotConfirmed
+}
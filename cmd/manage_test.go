@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// setupManageTest は #old-project を返すワークスペースを用意し、呼ばれた書き込み系
+// メソッドの記録を返す
+func setupManageTest(t *testing.T, archived bool) *[]string {
+	calls, _ := newFakeSlack(t, slackMethods{
+		"conversations.info": respond(map[string]interface{}{
+			"channel": map[string]interface{}{"id": "C1234567", "name": "old-project", "num_members": 3, "is_archived": archived},
+		}),
+		"conversations.rename": respond(map[string]interface{}{
+			"channel": map[string]interface{}{"id": "C1234567", "name": "new-project"},
+		}),
+	})
+	return calls
+}
+
+func runManageCmd(runE func(*cobra.Command, []string) error, input string, args ...string) (string, error) {
+	cmd := &cobra.Command{Use: "manage", RunE: runE}
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestChannelArchiveCmd(t *testing.T) {
+	t.Run("should archive after confirmation", func(t *testing.T) {
+		calls := setupManageTest(t, false)
+
+		output, err := runManageCmd(runChannelArchive, "y\n", "C1234567")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, "Archive #old-project (C1234567, 3 members)? [y/N]") || !strings.Contains(output, "Archived #old-project") {
+			t.Errorf("unexpected output: %s", output)
+		}
+		if len(*calls) != 1 || !strings.HasPrefix((*calls)[0], "conversations.archive ") {
+			t.Errorf("expected conversations.archive, got: %v", *calls)
+		}
+	})
+
+	t.Run("should abort when not confirmed", func(t *testing.T) {
+		calls := setupManageTest(t, false)
+
+		if _, err := runManageCmd(runChannelArchive, "n\n", "C1234567"); err != errNotConfirmed {
+			t.Errorf("expected abort, got: %v", err)
+		}
+		if _, err := runManageCmd(runChannelArchive, "", "C1234567"); err == nil || !strings.Contains(err.Error(), "--yes") {
+			t.Errorf("expected error asking for --yes, got: %v", err)
+		}
+		if len(*calls) != 0 {
+			t.Errorf("expected no changes, got: %v", *calls)
+		}
+	})
+
+	t.Run("should only preview with --dry-run", func(t *testing.T) {
+		calls := setupManageTest(t, false)
+
+		dryRun = true
+		defer func() { dryRun = false }()
+
+		output, err := runManageCmd(runChannelArchive, "", "C1234567")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, "Would archive #old-project") || len(*calls) != 0 {
+			t.Errorf("expected preview only, got: %s (%v)", output, *calls)
+		}
+	})
+
+	t.Run("should refuse archived channels", func(t *testing.T) {
+		setupManageTest(t, true)
+
+		if _, err := runManageCmd(runChannelArchive, "y\n", "C1234567"); err == nil || !strings.Contains(err.Error(), "already archived") {
+			t.Errorf("expected already archived error, got: %v", err)
+		}
+	})
+}
+
+func TestChannelLifecycleCmds(t *testing.T) {
+	t.Run("should create private channels", func(t *testing.T) {
+		newTestWorkspace(t, func(w http.ResponseWriter, r *http.Request) {
+			var data map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&data)
+			if r.URL.Path != "/api/conversations.create" || data["is_private"] != true {
+				t.Errorf("unexpected request: %s %v", r.URL.Path, data)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":      true,
+				"channel": map[string]interface{}{"id": "G1234567", "name": data["name"]},
+			})
+		})
+
+		createPrivate = true
+		defer func() { createPrivate = false }()
+
+		output, err := runManageCmd(runChannelCreate, "", "#inc-101")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, "Created private channel #inc-101 (G1234567)") {
+			t.Errorf("unexpected output: %s", output)
+		}
+	})
+
+	t.Run("should validate names before any request", func(t *testing.T) {
+		if _, err := runManageCmd(runChannelCreate, "", "Bad Name"); err == nil || !strings.Contains(err.Error(), "invalid channel name") {
+			t.Errorf("expected invalid name error, got: %v", err)
+		}
+		if _, err := runManageCmd(runChannelRename, "", "general", "Bad Name"); err == nil || !strings.Contains(err.Error(), "invalid channel name") {
+			t.Errorf("expected invalid name error, got: %v", err)
+		}
+	})
+
+	t.Run("should rename with --yes", func(t *testing.T) {
+		calls := setupManageTest(t, false)
+
+		assumeYes = true
+		defer func() { assumeYes = false }()
+
+		output, err := runManageCmd(runChannelRename, "", "C1234567", "new-project")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, "Renamed #old-project to #new-project") || len(*calls) != 1 {
+			t.Errorf("unexpected result: %s (%v)", output, *calls)
+		}
+	})
+
+	t.Run("should unarchive archived channels", func(t *testing.T) {
+		calls := setupManageTest(t, true)
+
+		output, err := runManageCmd(runChannelUnarchive, "", "C1234567")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, "Unarchived #old-project") || !strings.HasPrefix((*calls)[0], "conversations.unarchive ") {
+			t.Errorf("unexpected result: %s (%v)", output, *calls)
+		}
+	})
+}
@@ -0,0 +1,365 @@
+package cmd
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/oppai/slakctl/internal/printer"
+	"github.com/oppai/slakctl/pkg/slack"
+
+	"github.com/spf13/cobra"
+)
+
+var membersOutput string
+
+var channelTopicCmd = &cobra.Command{
+	Use:   "topic <channel> <text>",
+	Short: "Set the topic of a channel",
+	Long:  "Set the topic of a channel. Words after the channel are joined with spaces; pass \"\" to clear the topic.",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runChannelTopic,
+}
+
+var channelPurposeCmd = &cobra.Command{
+	Use:   "purpose <channel> <text>",
+	Short: "Set the purpose of a channel",
+	Long:  "Set the purpose (description) of a channel. Words after the channel are joined with spaces; pass \"\" to clear the purpose.",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runChannelPurpose,
+}
+
+var channelJoinCmd = &cobra.Command{
+	Use:   "join <channel>",
+	Short: "Join a channel",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runChannelJoin,
+}
+
+var channelLeaveCmd = &cobra.Command{
+	Use:   "leave <channel>",
+	Short: "Leave a channel",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runChannelLeave,
+}
+
+var channelInviteCmd = &cobra.Command{
+	Use:   "invite <channel> <user>...",
+	Short: "Invite users to a channel",
+	Long:  "Invite users to a channel. Users can be given by ID, user name or display name, with or without \"@\". Users who are already members are skipped.",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runChannelInvite,
+}
+
+var channelKickCmd = &cobra.Command{
+	Use:   "kick <channel> <user>...",
+	Short: "Remove users from a channel",
+	Long:  "Remove users from a channel. Users can be given by ID, user name or display name, with or without \"@\". Users who are not members are skipped.",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runChannelKick,
+}
+
+var channelMembersCmd = &cobra.Command{
+	Use:   "members <channel>",
+	Short: "List the members of a channel",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runChannelMembers,
+}
+
+func init() {
+	channelMembersCmd.Flags().StringVarP(&membersOutput, "output", "o", "table", "Output format: "+printer.Formats)
+
+	for _, c := range []*cobra.Command{channelTopicCmd, channelPurposeCmd, channelJoinCmd, channelLeaveCmd, channelInviteCmd, channelKickCmd} {
+		c.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without changing anything")
+	}
+	for _, c := range []*cobra.Command{channelTopicCmd, channelPurposeCmd, channelJoinCmd, channelLeaveCmd, channelInviteCmd, channelKickCmd, channelMembersCmd} {
+		channelCmd.AddCommand(c)
+	}
+}
+
+func runChannelTopic(cmd *cobra.Command, args []string) error {
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	channel, err := lookupChannel(cmd, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	topic := strings.Join(args[1:], " ")
+	if dryRun {
+		cmd.Printf("Would change topic of #%s from %q to %q\n", channel.Name, channel.Topic.Value, topic)
+		return nil
+	}
+
+	if err := client.SetTopic(cmd.Context(), channel.ID, topic); err != nil {
+		return err
+	}
+
+	cmd.Printf("Changed topic of #%s to %q\n", channel.Name, topic)
+	return nil
+}
+
+func runChannelPurpose(cmd *cobra.Command, args []string) error {
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	channel, err := lookupChannel(cmd, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	purpose := strings.Join(args[1:], " ")
+	if dryRun {
+		cmd.Printf("Would change purpose of #%s from %q to %q\n", channel.Name, channel.Purpose.Value, purpose)
+		return nil
+	}
+
+	if err := client.SetPurpose(cmd.Context(), channel.ID, purpose); err != nil {
+		return err
+	}
+
+	cmd.Printf("Changed purpose of #%s to %q\n", channel.Name, purpose)
+	return nil
+}
+
+func runChannelJoin(cmd *cobra.Command, args []string) error {
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	channel, err := lookupChannel(cmd, client, args[0])
+	if err != nil {
+		return err
+	}
+	if channel.IsMember {
+		cmd.Printf("Already a member of #%s\n", channel.Name)
+		return nil
+	}
+
+	if dryRun {
+		cmd.Printf("Would join #%s (%s)\n", channel.Name, channel.ID)
+		return nil
+	}
+
+	if _, err := client.JoinChannel(cmd.Context(), channel.ID); err != nil {
+		return err
+	}
+
+	cmd.Printf("Joined #%s\n", channel.Name)
+	return nil
+}
+
+func runChannelLeave(cmd *cobra.Command, args []string) error {
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	channel, err := lookupChannel(cmd, client, args[0])
+	if err != nil {
+		return err
+	}
+	if !channel.IsMember {
+		cmd.Printf("Not a member of #%s\n", channel.Name)
+		return nil
+	}
+
+	if dryRun {
+		cmd.Printf("Would leave #%s (%s)\n", channel.Name, channel.ID)
+		return nil
+	}
+
+	if _, err := client.LeaveChannel(cmd.Context(), channel.ID); err != nil {
+		return err
+	}
+
+	cmd.Printf("Left #%s\n", channel.Name)
+	return nil
+}
+
+func runChannelInvite(cmd *cobra.Command, args []string) error {
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	channel, users, members, err := lookupMembership(cmd, client, args[0], args[1:])
+	if err != nil {
+		return err
+	}
+
+	// 既にメンバーのユーザーが含まれると conversations.invite 全体が失敗するので除く
+	var invite []slack.User
+	for _, user := range users {
+		if members[user.ID] {
+			cmd.PrintErrf("@%s is already a member of #%s\n", user.Name, channel.Name)
+			continue
+		}
+		invite = append(invite, user)
+	}
+	if len(invite) == 0 {
+		return nil
+	}
+
+	if dryRun {
+		cmd.Printf("Would invite %s to #%s\n", userList(invite), channel.Name)
+		return nil
+	}
+
+	ids := make([]string, len(invite))
+	for i, user := range invite {
+		ids[i] = user.ID
+	}
+	if err := client.InviteToChannel(cmd.Context(), channel.ID, ids...); err != nil {
+		return err
+	}
+
+	cmd.Printf("Invited %s to #%s\n", userList(invite), channel.Name)
+	return nil
+}
+
+func runChannelKick(cmd *cobra.Command, args []string) error {
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	channel, users, members, err := lookupMembership(cmd, client, args[0], args[1:])
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		if !members[user.ID] {
+			cmd.PrintErrf("@%s is not a member of #%s\n", user.Name, channel.Name)
+			continue
+		}
+
+		if dryRun {
+			cmd.Printf("Would remove @%s from #%s\n", user.Name, channel.Name)
+			continue
+		}
+
+		// conversations.kick は 1 ユーザーずつしか受け付けない
+		if err := client.KickFromChannel(cmd.Context(), channel.ID, user.ID); err != nil {
+			return err
+		}
+		cmd.Printf("Removed @%s from #%s\n", user.Name, channel.Name)
+	}
+
+	return nil
+}
+
+// lookupMembership resolves the channel and users of invite and kick and
+// fetches the current members of the channel.
+func lookupMembership(cmd *cobra.Command, client *slack.Client, channelRef string, userRefs []string) (*slack.Channel, []slack.User, map[string]bool, error) {
+	channel, err := lookupChannel(cmd, client, channelRef)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	resolver := slack.NewResolver(client)
+	seen := map[string]bool{}
+	var users []slack.User
+	for _, ref := range userRefs {
+		user, err := resolver.ResolveUser(cmd.Context(), ref)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if !seen[user.ID] {
+			seen[user.ID] = true
+			users = append(users, *user)
+		}
+	}
+
+	memberIDs, err := client.ListChannelMembers(cmd.Context(), channel.ID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	members := make(map[string]bool, len(memberIDs))
+	for _, id := range memberIDs {
+		members[id] = true
+	}
+
+	return channel, users, members, nil
+}
+
+func userList(users []slack.User) string {
+	names := make([]string, len(users))
+	for i, user := range users {
+		names[i] = "@" + user.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func runChannelMembers(cmd *cobra.Command, args []string) error {
+	format, err := printer.ParseFormat(membersOutput)
+	if err != nil {
+		return err
+	}
+
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	channelID, err := slack.NewResolver(client).ResolveChannelID(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+
+	memberIDs, err := client.ListChannelMembers(cmd.Context(), channelID)
+	if err != nil {
+		return err
+	}
+
+	users, err := client.ListUsersContext(cmd.Context())
+	listed := err == nil
+	if !listed {
+		if cmd.Context().Err() != nil {
+			return err
+		}
+		// メンバーの ID は取れているので名前なしで続ける
+		cmd.PrintErrf("Warning: could not list users, members are shown by ID: %v\n", err)
+	}
+	byID := make(map[string]slack.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+
+	members := make([]slack.User, 0, len(memberIDs))
+	for _, id := range memberIDs {
+		user, ok := byID[id]
+		if !ok {
+			user = slack.User{ID: id}
+		}
+		if !ok && listed {
+			// 他のワークスペースのユーザーは users.list に含まれないので個別に引く
+			if info, err := client.GetUserInfo(cmd.Context(), id); err == nil {
+				user = *info
+			}
+		}
+		members = append(members, user)
+	}
+	sort.SliceStable(members, func(i, j int) bool { return members[i].Name < members[j].Name })
+
+	return printer.Print(cmd.OutOrStdout(), format, members, memberColumns)
+}
+
+// memberColumns are the columns of channel members -o table, wide, csv
+// and tsv.
+var memberColumns = []printer.Column[slack.User]{
+	{Header: "ID", Value: func(u slack.User) string { return u.ID }},
+	{Header: "NAME", Value: func(u slack.User) string { return u.Name }},
+	{Header: "DISPLAY NAME", Value: func(u slack.User) string { return u.Profile.DisplayName }},
+	{Header: "REAL NAME", Value: func(u slack.User) string { return u.RealName }},
+	{Header: "BOT", Wide: true, Value: func(u slack.User) string { return strconv.FormatBool(u.IsBot) }},
+	{Header: "DELETED", Wide: true, Value: func(u slack.User) string { return strconv.FormatBool(u.Deleted) }},
+	{Header: "EMAIL", Wide: true, Value: func(u slack.User) string { return u.Profile.Email }},
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// setupMembersTest は #oncall (メンバーは alice と他のワークスペースの zed) と
+// ユーザー一覧を返すワークスペースを用意し、呼ばれた書き込み系メソッドの記録を返す
+func setupMembersTest(t *testing.T) *[]string {
+	calls, _ := newFakeSlack(t, slackMethods{
+		"conversations.info": respond(map[string]interface{}{
+			"channel": map[string]interface{}{
+				"id": "C1234567", "name": "oncall", "is_member": true,
+				"topic": map[string]interface{}{"value": "on-call: alice"},
+			},
+		}),
+		"conversations.members": respond(map[string]interface{}{"members": []string{"U1", "UEXT"}}),
+		"conversations.list": respond(map[string]interface{}{
+			"channels": []map[string]interface{}{{"id": "C1234567", "name": "oncall"}},
+		}),
+		"users.list": respond(map[string]interface{}{
+			"members": []map[string]interface{}{
+				{"id": "U1", "name": "alice", "real_name": "Alice Liddell", "profile": map[string]interface{}{"display_name": "ali"}},
+				{"id": "U2", "name": "bob", "real_name": "Bob Builder"},
+			},
+		}),
+		"users.info": func(r *http.Request) map[string]interface{} {
+			users := map[string]string{"U2": "bob", "UEXT": "zed"}
+			id := r.URL.Query().Get("user")
+			return map[string]interface{}{"user": map[string]interface{}{"id": id, "name": users[id]}}
+		},
+	})
+	return calls
+}
+
+func TestChannelTopicCmd(t *testing.T) {
+	t.Run("should join words into the topic", func(t *testing.T) {
+		calls := setupMembersTest(t)
+
+		output, err := runManageCmd(runChannelTopic, "", "#oncall", "on-call:", "@bob")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, `Changed topic of #oncall to "on-call: @bob"`) {
+			t.Errorf("unexpected output: %s", output)
+		}
+		if len(*calls) != 1 || !strings.Contains((*calls)[0], `"topic":"on-call: @bob"`) {
+			t.Errorf("unexpected requests: %v", *calls)
+		}
+	})
+
+	t.Run("should show the old topic with --dry-run", func(t *testing.T) {
+		calls := setupMembersTest(t)
+
+		dryRun = true
+		defer func() { dryRun = false }()
+
+		output, err := runManageCmd(runChannelTopic, "", "C1234567", "on-call: bob")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, `Would change topic of #oncall from "on-call: alice" to "on-call: bob"`) || len(*calls) != 0 {
+			t.Errorf("expected preview only, got: %s (%v)", output, *calls)
+		}
+	})
+}
+
+func TestChannelJoinLeaveCmd(t *testing.T) {
+	calls := setupMembersTest(t)
+
+	output, err := runManageCmd(runChannelJoin, "", "C1234567")
+	if err != nil || !strings.Contains(output, "Already a member of #oncall") {
+		t.Errorf("unexpected result: %s (%v)", output, err)
+	}
+
+	output, err = runManageCmd(runChannelLeave, "", "C1234567")
+	if err != nil || !strings.Contains(output, "Left #oncall") {
+		t.Errorf("unexpected result: %s (%v)", output, err)
+	}
+	if len(*calls) != 1 || !strings.HasPrefix((*calls)[0], "conversations.leave ") {
+		t.Errorf("unexpected requests: %v", *calls)
+	}
+}
+
+func TestChannelInviteKickCmd(t *testing.T) {
+	t.Run("should skip users who are already members", func(t *testing.T) {
+		calls := setupMembersTest(t)
+
+		output, err := runManageCmd(runChannelInvite, "", "C1234567", "@alice", "bob", "@bob")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, "@alice is already a member of #oncall") || !strings.Contains(output, "Invited @bob to #oncall") {
+			t.Errorf("unexpected output: %s", output)
+		}
+		if len(*calls) != 1 || !strings.Contains((*calls)[0], `"users":"U2"`) {
+			t.Errorf("unexpected requests: %v", *calls)
+		}
+	})
+
+	t.Run("should kick members one by one", func(t *testing.T) {
+		calls := setupMembersTest(t)
+
+		output, err := runManageCmd(runChannelKick, "", "C1234567", "ali", "bob")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, "Removed @alice from #oncall") || !strings.Contains(output, "@bob is not a member of #oncall") {
+			t.Errorf("unexpected output: %s", output)
+		}
+		if len(*calls) != 1 || !strings.Contains((*calls)[0], `"user":"U1"`) {
+			t.Errorf("unexpected requests: %v", *calls)
+		}
+	})
+
+	t.Run("should report unknown users", func(t *testing.T) {
+		calls := setupMembersTest(t)
+
+		_, err := runManageCmd(runChannelInvite, "", "C1234567", "bobb")
+		if err == nil || !strings.Contains(err.Error(), "user not found: bobb") {
+			t.Errorf("expected user not found, got: %v", err)
+		}
+		if len(*calls) != 0 {
+			t.Errorf("expected no changes, got: %v", *calls)
+		}
+	})
+}
+
+func TestChannelMembersCmd(t *testing.T) {
+	setupMembersTest(t)
+
+	membersOutput = "table"
+	defer func() { membersOutput = "table" }()
+
+	output, err := runManageCmd(runChannelMembers, "", "C1234567")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 members, got: %s", output)
+	}
+	if !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "alice") || !strings.Contains(lines[1], "Alice Liddell") {
+		t.Errorf("unexpected output: %s", output)
+	}
+	if !strings.Contains(lines[2], "UEXT") || !strings.Contains(lines[2], "zed") {
+		t.Errorf("expected users missing from users.list to be looked up, got: %s", output)
+	}
+}
+
+func TestChannelMembersWithoutUsers(t *testing.T) {
+	missingScope := respond(map[string]interface{}{"ok": false, "error": "missing_scope", "needed": "users:read"})
+	newFakeSlack(t, slackMethods{
+		"conversations.members": respond(map[string]interface{}{"members": []string{"U1", "U2"}}),
+		"users.list":            missingScope,
+		"users.info":            missingScope,
+	})
+
+	output, err := runManageCmd(runChannelMembers, "", "C1234567")
+	if err != nil {
+		t.Fatalf("expected members by ID, got: %v", err)
+	}
+	if !strings.Contains(output, "Warning: could not list users, members are shown by ID") {
+		t.Errorf("expected warning, got: %s", output)
+	}
+	if !strings.Contains(output, "U1") || !strings.Contains(output, "U2") {
+		t.Errorf("expected member IDs, got: %s", output)
+	}
+}
@@ -3,8 +3,7 @@ package cmd
 import (
 	"fmt"
 
-	"slakctl/internal/config"
-	"slakctl/internal/slack"
+	"github.com/oppai/slakctl/pkg/slack"
 
 	"github.com/spf13/cobra"
 )
@@ -12,7 +11,7 @@ import (
 var postCmd = &cobra.Command{
 	Use:   "post [channel] [message]",
 	Short: "Post a message to a channel",
-	Long:  "Post a message to the specified channel. Channel can be specified with or without the # prefix.",
+	Long:  "Post a message to the specified channel. Channel can be a name with or without the # prefix, a channel ID, or @user for a direct message.",
 	Args:  cobra.ExactArgs(2),
 	RunE:  runPost,
 }
@@ -21,25 +20,24 @@ func runPost(cmd *cobra.Command, args []string) error {
 	if len(args) < 2 {
 		return fmt.Errorf("both channel and message arguments are required")
 	}
-	
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
 
-	if cfg.Token == "" {
-		return fmt.Errorf("no authentication token found. Please run 'slakctl auth' first")
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
 	}
 
-	client := slack.NewClient(cfg.Token)
-	
 	channel := args[0]
 	message := args[1]
 
-	if err := client.PostMessage(channel, message); err != nil {
+	target, err := slack.NewResolver(client).ResolveChannelID(cmd.Context(), channel)
+	if err != nil {
+		return err
+	}
+
+	if err := client.PostMessageContext(cmd.Context(), target, message); err != nil {
 		return fmt.Errorf("failed to post message: %w", err)
 	}
 
 	fmt.Printf("Message posted successfully to %s\n", channel)
 	return nil
-}
\ No newline at end of file
+}
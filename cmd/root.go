@@ -1,23 +1,129 @@
 package cmd
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/oppai/slakctl/internal/cache"
+	"github.com/oppai/slakctl/internal/config"
+	"github.com/oppai/slakctl/internal/timefmt"
+	"github.com/oppai/slakctl/pkg/slack"
+
 	"github.com/spf13/cobra"
 )
 
+// APIURLEnv overrides the Slack Web API endpoint for every command.
+const APIURLEnv = "SLAKCTL_API_URL"
+
+var (
+	noCache      bool
+	refreshCache bool
+	timeZone     string
+	timeFormat   string
+)
+
 var rootCmd = &cobra.Command{
 	Use:   "slakctl",
 	Short: "A CLI tool for managing Slack workspaces",
 	Long:  "slakctl is a command-line tool for managing Slack workspaces using personal tokens.",
+	// main が Hint と一緒にエラーを表示する
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return timefmt.Default.Configure(timeZone, timeFormat)
+	},
 }
 
+// Execute runs the root command. The first SIGINT or SIGTERM cancels the
+// command's context so in-flight requests and paginated fetches stop
+// cleanly; a second signal terminates the process immediately.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	// 結果をパイプで渡せるよう標準出力に書き出す（エラーと進捗は標準エラー出力）
+	rootCmd.SetOut(os.Stdout)
+
+	return rootCmd.ExecuteContext(ctx)
+}
+
+// authenticatedClient loads the saved token and returns a client for it.
+func authenticatedClient() (*slack.Client, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.Token == "" {
+		return nil, errNoToken
+	}
+
+	if noCache {
+		return newClient(cfg.Token), nil
+	}
+
+	c, err := workspaceCache(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(cfg.Token, slack.WithCache(c)), nil
+}
+
+func newClient(token string, opts ...slack.Option) *slack.Client {
+	if apiURL := os.Getenv(APIURLEnv); apiURL != "" {
+		opts = append(opts, slack.WithBaseURL(apiURL))
+	}
+	return slack.NewClient(token, opts...)
+}
+
+// workspaceCache opens the on-disk cache of the configured workspace.
+func workspaceCache(cfg *config.Config) (*cache.Cache, error) {
+	c, err := cache.Open(workspaceKey(cfg))
+	if err != nil {
+		return nil, err
+	}
+	c.Refresh = refreshCache
+	return c, nil
+}
+
+// workspaceKey names the cache directory of the configured workspace. The
+// team ID is saved on authentication; older configs fall back to a hash of
+// the token and API endpoint.
+func workspaceKey(cfg *config.Config) string {
+	apiURL := os.Getenv(APIURLEnv)
+	if cfg.TeamID != "" && apiURL == "" {
+		return cfg.TeamID
+	}
+
+	sum := sha256.Sum256([]byte(apiURL + "\x00" + cfg.Token))
+	return "token-" + hex.EncodeToString(sum[:8])
+}
+
+func isCancelled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
 }
 
 func init() {
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Do not read or write the local cache of channels, users and emoji")
+	rootCmd.PersistentFlags().BoolVar(&refreshCache, "refresh", false, "Ignore cached channels, users and emoji and fetch them again")
+	rootCmd.PersistentFlags().StringVar(&timeZone, "tz", "local", "Time zone for printed times and date flags: local, UTC, a name like Asia/Tokyo, or an offset like +09:00")
+	rootCmd.PersistentFlags().StringVar(&timeFormat, "time-format", timefmt.DefaultLayout, "How to print times: relative, iso, unix, or a Go layout")
 	rootCmd.AddCommand(authCmd)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(searchCmd)
 	rootCmd.AddCommand(channelCmd)
 	rootCmd.AddCommand(postCmd)
-}
\ No newline at end of file
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(cacheCmd)
+	rootCmd.AddCommand(emojiCmd)
+}
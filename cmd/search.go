@@ -3,89 +3,194 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
-	"slakctl/internal/config"
-	"slakctl/internal/slack"
+	"github.com/oppai/slakctl/internal/printer"
+	"github.com/oppai/slakctl/internal/timefmt"
+	"github.com/oppai/slakctl/pkg/slack"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	searchCount    int
+	searchCount    string
 	searchFormat   string
 	searchProgress bool
+	searchSort     string
+	searchAsc      bool
+	searchDesc     bool
+
+	searchIn     []string
+	searchFrom   []string
+	searchTo     []string
+	searchBefore string
+	searchAfter  string
+	searchOn     string
+	searchDuring string
+	searchHas    []string
+	searchIs     []string
+	searchExact  bool
 )
 
 var searchCmd = &cobra.Command{
-	Use:   "search [keyword]",
+	Use:   "search [term...]",
 	Short: "Search for messages across channels",
-	Long:  "Search for messages containing the specified keyword across all channels in the workspace.\n\nThis command supports pagination to fetch large numbers of results efficiently.",
-	Args:  cobra.ExactArgs(1),
+	Long:  "Search for messages containing the given terms across all channels in the workspace.\n\nNarrow the search with --in, --from, --to, --before, --after, --on, --during, --has and --is instead of Slack's modifier syntax. Channel and user names are checked before searching, so typos are reported rather than silently matching nothing. Terms may also use Slack's modifiers directly.\n\nThis command supports pagination to fetch large numbers of results efficiently. Slack returns at most 10000 matches per query; when sorting by timestamp, larger counts and --count all split the search into date windows to get past that limit.",
 	RunE:  runSearch,
 }
 
-func init() {
-	searchCmd.Flags().IntVarP(&searchCount, "count", "c", 20, "Number of messages to return (max 1000)")
-	searchCmd.Flags().StringVarP(&searchFormat, "format", "f", "text", "Output format: text, json, or custom format string")
-	searchCmd.Flags().BoolVarP(&searchProgress, "progress", "p", true, "Show progress during search")
+var searchFilesCmd = &cobra.Command{
+	Use:   "files [term...]",
+	Short: "Search for files across channels",
+	Long:  "Search for files, such as documents and screenshots, matching the given terms. Takes the same filters and options as 'slakctl search'.\n\nCustom formats can use {id}, {name}, {title}, {filetype}, {size}, {user}, {user_id}, {channels}, {created} and {permalink}.",
+	RunE:  runSearchFiles,
 }
 
-func runSearch(cmd *cobra.Command, args []string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("keyword argument is required")
-	}
+var searchAllCmd = &cobra.Command{
+	Use:   "all [term...]",
+	Short: "Search for messages and files at once",
+	Long:  "Search for messages and files matching the given terms with one request per page. Takes the same filters and options as 'slakctl search'; --count applies to messages and files separately and cannot be 'all'.\n\nCustom formats are applied to messages and then files, each with its own placeholders.",
+	RunE:  runSearchAll,
+}
 
-	// Validate count
-	if searchCount < 1 || searchCount > 1000 {
-		return fmt.Errorf("count must be between 1 and 1000")
-	}
+func init() {
+	searchCmd.PersistentFlags().StringVarP(&searchCount, "count", "c", "20", "Number of results to return, or 'all'")
+	searchCmd.PersistentFlags().StringVarP(&searchFormat, "format", "f", "text", "Output format: text, json, or a custom format such as '{{.channel.name}}: {{.text}}'")
+	searchCmd.PersistentFlags().BoolVarP(&searchProgress, "progress", "p", true, "Show progress during search")
+	searchCmd.PersistentFlags().StringVar(&searchSort, "sort", slack.SearchSortTimestamp, "Sort by "+slack.SearchSortTimestamp+" or "+slack.SearchSortScore)
+	searchCmd.PersistentFlags().BoolVar(&searchAsc, "asc", false, "Return the oldest, or least relevant, results first")
+	searchCmd.PersistentFlags().BoolVar(&searchDesc, "desc", false, "Return the newest, or most relevant, results first (default)")
+	searchCmd.MarkFlagsMutuallyExclusive("asc", "desc")
+
+	searchCmd.PersistentFlags().StringSliceVar(&searchIn, "in", nil, "Only search these channels, or direct messages with @user (repeatable)")
+	searchCmd.PersistentFlags().StringSliceVar(&searchFrom, "from", nil, "Only results from these users (repeatable)")
+	searchCmd.PersistentFlags().StringSliceVar(&searchTo, "to", nil, "Only results in direct messages to these users (repeatable)")
+	searchCmd.PersistentFlags().StringVar(&searchBefore, "before", "", "Only results before this date (e.g. 2024-05-01, 7d)")
+	searchCmd.PersistentFlags().StringVar(&searchAfter, "after", "", "Only results after this date (e.g. 2024-05-01, 30d)")
+	searchCmd.PersistentFlags().StringVar(&searchOn, "on", "", "Only results on this date")
+	searchCmd.PersistentFlags().StringVar(&searchDuring, "during", "", "Only results during a year, month or period (2024, 2024-05, may, week, ...)")
+	searchCmd.PersistentFlags().StringSliceVar(&searchHas, "has", nil, "Only messages with a "+strings.Join(slack.SearchHas, ", "))
+	searchCmd.PersistentFlags().StringSliceVar(&searchIs, "is", nil, "Only messages that are a "+strings.Join(slack.SearchIs, ", "))
+	searchCmd.PersistentFlags().BoolVar(&searchExact, "exact", false, "Search for the terms as one exact phrase")
+
+	searchCmd.AddCommand(searchFilesCmd)
+	searchCmd.AddCommand(searchAllCmd)
+}
 
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+// searchQueryFromFlags builds the query from the search terms and flags.
+// Names in --in, --from and --to are resolved later by resolveSearchQuery.
+func searchQueryFromFlags(args []string) (slack.SearchQuery, error) {
+	query := slack.SearchQuery{
+		Terms:  args,
+		Exact:  searchExact,
+		In:     searchIn,
+		From:   searchFrom,
+		To:     searchTo,
+		During: searchDuring,
+		Has:    searchHas,
+		Is:     searchIs,
 	}
 
-	if cfg.Token == "" {
-		return fmt.Errorf("no authentication token found. Please run 'slakctl auth' first")
+	now := time.Now()
+	dates := []struct {
+		flag  string
+		value string
+		dest  *time.Time
+	}{
+		{"--before", searchBefore, &query.Before},
+		{"--after", searchAfter, &query.After},
+		{"--on", searchOn, &query.On},
 	}
-
-	client := slack.NewClient(cfg.Token)
-
-	keyword := args[0]
-
-	options := slack.SearchOptions{
-		MaxResults: searchCount,
+	for _, date := range dates {
+		t, err := parseTimeFlag(date.value, now)
+		if err != nil {
+			return query, fmt.Errorf("invalid %s: %w", date.flag, err)
+		}
+		*date.dest = t
 	}
 
-	var results *slack.SearchResult
-	var err2 error
+	return query, query.Validate()
+}
 
-	if searchProgress {
-		cmd.Println("Searching messages...")
-		startTime := time.Now()
+// resolveSearchQuery replaces the channel and user names of query with
+// canonical names and IDs, failing with suggestions for unknown names.
+func resolveSearchQuery(cmd *cobra.Command, client *slack.Client, query *slack.SearchQuery) error {
+	resolver := slack.NewResolver(client)
+
+	resolveUsers := func(refs []string) ([]string, error) {
+		ids := make([]string, len(refs))
+		for i, ref := range refs {
+			user, err := resolver.ResolveUser(cmd.Context(), ref)
+			if err != nil {
+				return nil, err
+			}
+			ids[i] = user.ID
+		}
+		return ids, nil
+	}
 
-		options.ProgressFunc = func(current, total int) {
-			elapsed := time.Since(startTime)
-			if total > 0 {
-				cmd.Printf("\rFound %d/%d messages (elapsed: %v)", current, total, elapsed.Round(time.Millisecond))
-			} else {
-				cmd.Printf("\rFound %d messages (elapsed: %v)", current, elapsed.Round(time.Millisecond))
+	in := make([]string, len(query.In))
+	for i, ref := range query.In {
+		if strings.HasPrefix(ref, "@") {
+			// @user は相手とのダイレクトメッセージを指す
+			user, err := resolver.ResolveUser(cmd.Context(), ref)
+			if err != nil {
+				return err
 			}
+			in[i] = user.ID
+			continue
+		}
+		channel, err := resolver.ResolveChannel(cmd.Context(), ref)
+		if err != nil {
+			return err
 		}
+		in[i] = channel.Name
+		if channel.Name == "" {
+			in[i] = channel.ID
+		}
+	}
+	query.In = in
 
-		results, err2 = client.SearchWithOptions(keyword, options)
+	var err error
+	if query.From, err = resolveUsers(query.From); err != nil {
+		return err
+	}
+	if query.To, err = resolveUsers(query.To); err != nil {
+		return err
+	}
+	return nil
+}
 
-		if err2 == nil {
-			cmd.Println() // 改行
-		}
-	} else {
-		results, err2 = client.SearchWithOptions(keyword, options)
+func runSearch(cmd *cobra.Command, args []string) error {
+	if err := validateFormat(searchFormat, messagePlaceholders); err != nil {
+		return err
 	}
 
-	if err2 != nil {
-		return fmt.Errorf("search failed: %w", err2)
+	client, query, options, err := prepareSearch(cmd, args)
+	if err != nil {
+		return err
+	}
+	keyword := query.String()
+
+	results, err := searchWithProgress(cmd, "messages", &options, func() (*slack.SearchResult, error) {
+		// 時刻順なら日付ごとに区切ってページ上限を超えて取得できる
+		if options.Sort == slack.SearchSortTimestamp {
+			return client.SearchAllContext(cmd.Context(), query, options)
+		}
+		return client.SearchContext(cmd.Context(), keyword, options)
+	})
+	if err != nil {
+		if !isCancelled(err) || results == nil || len(results.Matches) == 0 {
+			return fmt.Errorf("search failed: %w", err)
+		}
+		// 中断された場合はそれまでに取得できた分を表示する
+		cmd.PrintErrf("Interrupted, showing %d messages fetched so far\n", len(results.Matches))
+		if err := formatSearchResults(cmd, results, keyword, searchFormat); err != nil {
+			return err
+		}
+		return err
 	}
 
 	if len(results.Matches) == 0 {
@@ -106,6 +211,157 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	return formatSearchResults(cmd, results, keyword, searchFormat)
 }
 
+func runSearchFiles(cmd *cobra.Command, args []string) error {
+	if err := validateFormat(searchFormat, filePlaceholders); err != nil {
+		return err
+	}
+
+	client, query, options, err := prepareSearch(cmd, args)
+	if err != nil {
+		return err
+	}
+	keyword := query.String()
+
+	results, err := searchWithProgress(cmd, "files", &options, func() (*slack.FileSearchResult, error) {
+		if options.Sort == slack.SearchSortTimestamp {
+			return client.SearchAllFilesContext(cmd.Context(), query, options)
+		}
+		return client.SearchFilesContext(cmd.Context(), keyword, options)
+	})
+	if err != nil {
+		if !isCancelled(err) || results == nil || len(results.Matches) == 0 {
+			return fmt.Errorf("search failed: %w", err)
+		}
+		cmd.PrintErrf("Interrupted, showing %d files fetched so far\n", len(results.Matches))
+		if err := formatFileSearchResults(cmd, results, keyword, searchFormat); err != nil {
+			return err
+		}
+		return err
+	}
+
+	if len(results.Matches) == 0 && searchFormat != "json" {
+		cmd.Printf("No files found containing '%s'\n", keyword)
+		return nil
+	}
+	return formatFileSearchResults(cmd, results, keyword, searchFormat)
+}
+
+func runSearchAll(cmd *cobra.Command, args []string) error {
+	if searchCount == "all" {
+		return fmt.Errorf("--count all is not supported by search all: use 'slakctl search' or 'slakctl search files'")
+	}
+	if err := validateFormat(searchFormat, messagePlaceholders); err != nil {
+		return err
+	}
+	if err := validateFormat(searchFormat, filePlaceholders); err != nil {
+		return err
+	}
+
+	client, query, options, err := prepareSearch(cmd, args)
+	if err != nil {
+		return err
+	}
+	keyword := query.String()
+
+	results, err := searchWithProgress(cmd, "messages and files", &options, func() (*slack.CombinedSearchResult, error) {
+		return client.SearchMessagesAndFilesContext(cmd.Context(), keyword, options)
+	})
+	if err != nil {
+		if !isCancelled(err) || results == nil || results.Messages.Total+results.Files.Total == 0 {
+			return fmt.Errorf("search failed: %w", err)
+		}
+		cmd.PrintErrf("Interrupted, showing %d messages and %d files fetched so far\n", results.Messages.Total, results.Files.Total)
+		if err := formatCombinedSearchResults(cmd, results, keyword, searchFormat); err != nil {
+			return err
+		}
+		return err
+	}
+
+	if results.Messages.Total+results.Files.Total == 0 && searchFormat != "json" {
+		cmd.Printf("No messages or files found containing '%s'\n", keyword)
+		return nil
+	}
+	return formatCombinedSearchResults(cmd, results, keyword, searchFormat)
+}
+
+// prepareSearch validates the flags shared by the search commands, builds
+// and resolves the query, and returns the options for the client.
+func prepareSearch(cmd *cobra.Command, args []string) (*slack.Client, slack.SearchQuery, slack.SearchOptions, error) {
+	var options slack.SearchOptions
+
+	query, err := searchQueryFromFlags(args)
+	if err != nil {
+		return nil, query, options, err
+	}
+
+	// Validate count
+	count, err := parseSearchCount(searchCount)
+	if err != nil {
+		return nil, query, options, err
+	}
+	if searchSort != slack.SearchSortTimestamp && searchSort != slack.SearchSortScore {
+		return nil, query, options, fmt.Errorf("invalid sort %q: use %s or %s", searchSort, slack.SearchSortTimestamp, slack.SearchSortScore)
+	}
+	if count == 0 && searchSort != slack.SearchSortTimestamp {
+		return nil, query, options, fmt.Errorf("--count all requires --sort %s", slack.SearchSortTimestamp)
+	}
+
+	client, err := authenticatedClient()
+	if err != nil {
+		return nil, query, options, err
+	}
+
+	if err := resolveSearchQuery(cmd, client, &query); err != nil {
+		return nil, query, options, err
+	}
+
+	options = slack.SearchOptions{
+		MaxResults: count,
+		Sort:       searchSort,
+		Ascending:  searchAsc && !searchDesc,
+		Location:   timefmt.Default.Location,
+	}
+	return client, query, options, nil
+}
+
+// searchWithProgress runs search, reporting the number of matches fetched
+// to stderr when --progress is set.
+func searchWithProgress[T any](cmd *cobra.Command, noun string, options *slack.SearchOptions, search func() (*T, error)) (*T, error) {
+	if !searchProgress {
+		return search()
+	}
+
+	cmd.PrintErrf("Searching %s...\n", noun)
+	startTime := time.Now()
+
+	options.ProgressFunc = func(current, total int) {
+		elapsed := time.Since(startTime)
+		if total > 0 {
+			cmd.PrintErrf("\rFound %d/%d %s (elapsed: %v)", current, total, noun, elapsed.Round(time.Millisecond))
+		} else {
+			cmd.PrintErrf("\rFound %d %s (elapsed: %v)", current, noun, elapsed.Round(time.Millisecond))
+		}
+	}
+
+	results, err := search()
+	if err == nil || results != nil {
+		cmd.PrintErrln() // 改行
+	}
+	return results, err
+}
+
+// parseSearchCount parses --count, returning 0 for "all".
+func parseSearchCount(value string) (int, error) {
+	if value == "all" {
+		return 0, nil
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 1 {
+		return 0, fmt.Errorf("count must be a positive number or 'all'")
+	}
+	return count, nil
+}
+
 func formatSearchResults(cmd *cobra.Command, results *slack.SearchResult, keyword, format string) error {
 	switch format {
 	case "json":
@@ -124,38 +380,102 @@ func formatSearchResults(cmd *cobra.Command, results *slack.SearchResult, keywor
 		cmd.Printf("Found %d messages containing '%s' (total: %d):\n\n", len(results.Matches), keyword, results.Total)
 
 		for _, msg := range results.Matches {
-			channelName := msg.Channel.Name
-			if channelName == "" {
-				channelName = msg.Channel.ID
-			}
+			printMessageText(cmd, msg, "")
+			cmd.Println("---")
+		}
 
-			username := msg.Username
-			if username == "" {
-				username = msg.User
-			}
+	default:
+		return printFormatted(cmd, format, messagePlaceholders, results.Matches)
+	}
 
-			cmd.Printf("Channel: #%s\n", channelName)
-			cmd.Printf("User: %s\n", username)
-			cmd.Printf("Text: %s\n", strings.TrimSpace(msg.Text))
-			cmd.Printf("Timestamp: %s\n", msg.TS)
-			if msg.Permalink != "" {
-				cmd.Printf("Link: %s\n", msg.Permalink)
-			}
+	return nil
+}
+
+func formatFileSearchResults(cmd *cobra.Command, results *slack.FileSearchResult, keyword, format string) error {
+	switch format {
+	case "json":
+		matches := results.Matches
+		if matches == nil {
+			matches = []slack.File{}
+		}
+		output := map[string]interface{}{
+			"files": matches,
+			"total": results.Total,
+			"query": keyword,
+		}
+		jsonOutput, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		cmd.Println(string(jsonOutput))
+
+	case "text":
+		cmd.Printf("Found %d files containing '%s' (total: %d):\n\n", len(results.Matches), keyword, results.Total)
+
+		for _, file := range results.Matches {
+			printFileText(cmd, file, "")
 			cmd.Println("---")
 		}
 
 	default:
-		// Custom format string - jq-like formatting
-		for _, msg := range results.Matches {
-			output := formatMessage(msg, format)
-			cmd.Println(output)
+		return printFormatted(cmd, format, filePlaceholders, results.Matches)
+	}
+
+	return nil
+}
+
+func formatCombinedSearchResults(cmd *cobra.Command, results *slack.CombinedSearchResult, keyword, format string) error {
+	switch format {
+	case "json":
+		messages, files := results.Messages.Matches, results.Files.Matches
+		if messages == nil {
+			messages = []slack.Message{}
+		}
+		if files == nil {
+			files = []slack.File{}
+		}
+		output := map[string]interface{}{
+			"messages": map[string]interface{}{"matches": messages, "total": results.Messages.Total},
+			"files":    map[string]interface{}{"matches": files, "total": results.Files.Total},
+			"query":    keyword,
+		}
+		jsonOutput, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		cmd.Println(string(jsonOutput))
+
+	case "text":
+		cmd.Printf("Found %d messages and %d files containing '%s':\n", results.Messages.Total, results.Files.Total, keyword)
+
+		if len(results.Messages.Matches) > 0 {
+			cmd.Print("\nMessages:\n\n")
+			for _, msg := range results.Messages.Matches {
+				printMessageText(cmd, msg, "")
+				cmd.Println("---")
+			}
 		}
+		if len(results.Files.Matches) > 0 {
+			cmd.Print("\nFiles:\n\n")
+			for _, file := range results.Files.Matches {
+				printFileText(cmd, file, "")
+				cmd.Println("---")
+			}
+		}
+
+	default:
+		if err := printFormatted(cmd, format, messagePlaceholders, results.Messages.Matches); err != nil {
+			return err
+		}
+		return printFormatted(cmd, format, filePlaceholders, results.Files.Matches)
 	}
 
 	return nil
 }
 
-func formatMessage(msg slack.Message, format string) string {
+// printMessageText prints msg in the multi-line "text" format, prefixing
+// every line with indent.
+func printMessageText(cmd *cobra.Command, msg slack.Message, indent string) {
 	channelName := msg.Channel.Name
 	if channelName == "" {
 		channelName = msg.Channel.ID
@@ -166,17 +486,96 @@ func formatMessage(msg slack.Message, format string) string {
 		username = msg.User
 	}
 
-	// Simple template replacement
-	output := format
-	output = strings.ReplaceAll(output, "{channel}", channelName)
-	output = strings.ReplaceAll(output, "{user}", username)
-	output = strings.ReplaceAll(output, "{text}", strings.TrimSpace(msg.Text))
-	output = strings.ReplaceAll(output, "{timestamp}", msg.TS)
-	output = strings.ReplaceAll(output, "{permalink}", msg.Permalink)
-	output = strings.ReplaceAll(output, "{channel_id}", msg.Channel.ID)
-	output = strings.ReplaceAll(output, "{user_id}", msg.User)
-	output = strings.ReplaceAll(output, "\\n", "\n")
-	output = strings.ReplaceAll(output, "\\t", "\t")
+	if msg.Channel.IsIM {
+		cmd.Printf("%sChannel: @%s\n", indent, channelName)
+	} else {
+		cmd.Printf("%sChannel: #%s\n", indent, channelName)
+	}
+	cmd.Printf("%sUser: %s\n", indent, username)
+	cmd.Printf("%sText: %s\n", indent, strings.TrimSpace(msg.Text))
+	cmd.Printf("%sTimestamp: %s\n", indent, msg.TS)
+	cmd.Printf("%sTime: %s\n", indent, timefmt.Default.FormatTS(msg.TS))
+	if msg.Permalink != "" {
+		cmd.Printf("%sLink: %s\n", indent, msg.Permalink)
+	}
+}
+
+// printFileText prints file in the multi-line "text" format, prefixing
+// every line with indent.
+func printFileText(cmd *cobra.Command, file slack.File, indent string) {
+	cmd.Printf("%sName: %s\n", indent, file.Name)
+	if file.Title != "" && file.Title != file.Name {
+		cmd.Printf("%sTitle: %s\n", indent, file.Title)
+	}
+	cmd.Printf("%sType: %s, %s\n", indent, file.Filetype, printer.FormatSize(file.Size))
+	cmd.Printf("%sUser: %s\n", indent, fileUploader(file))
+	if channels := file.SharedIn(); len(channels) > 0 {
+		cmd.Printf("%sChannels: %s\n", indent, strings.Join(channels, ", "))
+	}
+	if file.Created != 0 {
+		cmd.Printf("%sCreated: %s\n", indent, timefmt.Default.FormatUnix(file.Created))
+	}
+	if file.Permalink != "" {
+		cmd.Printf("%sLink: %s\n", indent, file.Permalink)
+	}
+}
+
+// messagePlaceholders and filePlaceholders map the {name} placeholders of
+// custom formats, which predate templates, to template expressions.
+var (
+	messagePlaceholders = map[string]string{
+		"channel":    "{{or .channel.name .channel.id}}",
+		"channel_id": "{{.channel.id}}",
+		"user":       "{{or .username .user}}",
+		"user_id":    "{{.user}}",
+		"text":       "{{trim .text}}",
+		"timestamp":  "{{.ts}}",
+		"time":       "{{time .ts}}",
+		"permalink":  "{{.permalink}}",
+	}
+	filePlaceholders = map[string]string{
+		"id":        "{{.id}}",
+		"name":      "{{.name}}",
+		"title":     "{{.title}}",
+		"filetype":  "{{.filetype}}",
+		"size":      "{{size .size}}",
+		"user":      "{{or .username .user}}",
+		"user_id":   "{{.user}}",
+		"channels":  `{{join "," .channels .groups .ims}}`,
+		"created":   "{{time .created}}",
+		"permalink": "{{.permalink}}",
+	}
+)
+
+// validateFormat reports an invalid custom format before any request is
+// made.
+func validateFormat(format string, placeholders map[string]string) error {
+	if format == "text" || format == "json" {
+		return nil
+	}
+	_, err := printer.ParseTemplate(format, placeholders)
+	return err
+}
+
+// printFormatted prints items one per line with a custom format.
+func printFormatted[T any](cmd *cobra.Command, format string, placeholders map[string]string, items []T) error {
+	tmpl, err := printer.ParseTemplate(format, placeholders)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		output, err := tmpl.Execute(item)
+		if err != nil {
+			return err
+		}
+		cmd.Println(output)
+	}
+	return nil
+}
 
-	return output
+func fileUploader(file slack.File) string {
+	if file.Username != "" {
+		return file.Username
+	}
+	return file.User
 }
@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func setupSearchTest(t *testing.T, queries *[]url.Values) {
+	t.Helper()
+
+	fileMatches := map[string]interface{}{
+		"matches": []map[string]interface{}{
+			{"id": "F0000001", "name": "diagram.png", "title": "Deploy diagram", "filetype": "png", "size": 2048, "user": "U000ALICE", "created": 1714521600, "channels": []string{"C0000OPS"}, "permalink": "https://example.slack.com/files/F0000001"},
+		},
+		"total":      1,
+		"pagination": map[string]int{"page": 1, "page_count": 1},
+	}
+	newFakeSlack(t, slackMethods{
+		"conversations.list": respond(map[string]interface{}{
+			"channels": []map[string]interface{}{
+				{"id": "C0000OPS", "name": "ops"},
+				{"id": "C0000DEV", "name": "dev"},
+			},
+		}),
+		"users.list": respond(map[string]interface{}{
+			"members": []map[string]interface{}{
+				{"id": "U000ALICE", "name": "alice"},
+				{"id": "U0000BOB", "name": "bob"},
+			},
+		}),
+		"search.messages": func(r *http.Request) map[string]interface{} {
+			*queries = append(*queries, r.URL.Query())
+			return map[string]interface{}{
+				"messages": map[string]interface{}{
+					"matches": []map[string]interface{}{
+						{"type": "message", "user": "U000ALICE", "username": "alice", "text": "deploy failed", "ts": "1714521600.000100", "channel": map[string]string{"id": "C0000OPS", "name": "ops"}},
+					},
+					"total":      1,
+					"pagination": map[string]int{"per_page": 20, "total_count": 1, "page": 1, "page_count": 1},
+				},
+			}
+		},
+		"search.files": func(r *http.Request) map[string]interface{} {
+			*queries = append(*queries, r.URL.Query())
+			return map[string]interface{}{"files": fileMatches}
+		},
+		"search.all": func(r *http.Request) map[string]interface{} {
+			*queries = append(*queries, r.URL.Query())
+			return map[string]interface{}{
+				"files": fileMatches,
+				"messages": map[string]interface{}{
+					"matches":    []map[string]interface{}{{"type": "message", "user": "U000ALICE", "username": "alice", "text": "deploy failed", "ts": "1714521600.000100", "channel": map[string]string{"id": "C0000OPS", "name": "ops"}}},
+					"total":      1,
+					"pagination": map[string]int{"page": 1, "page_count": 1},
+				},
+			}
+		},
+	})
+
+	searchProgress = false
+	t.Cleanup(func() {
+		searchProgress = true
+		searchIn, searchFrom, searchTo, searchHas, searchIs = nil, nil, nil, nil, nil
+		searchBefore, searchAfter, searchOn, searchDuring = "", "", "", ""
+		searchExact = false
+		searchCount, searchSort, searchAsc, searchFormat = "20", "timestamp", false, "text"
+	})
+}
+
+func TestSearchQueryFlags(t *testing.T) {
+	t.Run("should compile flags into a query", func(t *testing.T) {
+		var queries []url.Values
+		setupSearchTest(t, &queries)
+		searchIn = []string{"#ops", "@bob"}
+		searchFrom = []string{"alice"}
+		searchAfter = "2024-04-30"
+		searchHas = []string{"link"}
+
+		output, err := runManageCmd(runSearch, "", "deploy", "failed")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		want := "deploy failed in:#ops in:<@U0000BOB> from:<@U000ALICE> after:2024-04-30 has:link"
+		if len(queries) != 1 || queries[0].Get("query") != want {
+			t.Errorf("unexpected queries: %q", queries)
+		}
+		if !strings.Contains(output, "Found 1 messages containing '"+want+"'") {
+			t.Errorf("unexpected output: %s", output)
+		}
+	})
+
+	t.Run("should search with filters only", func(t *testing.T) {
+		var queries []url.Values
+		setupSearchTest(t, &queries)
+		searchFrom = []string{"@bob"}
+		searchDuring = "2024-05"
+
+		if _, err := runManageCmd(runSearch, ""); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(queries) != 1 || queries[0].Get("query") != "from:<@U0000BOB> during:2024-05" {
+			t.Errorf("unexpected queries: %q", queries)
+		}
+	})
+
+	t.Run("should report unknown names before searching", func(t *testing.T) {
+		var queries []url.Values
+		setupSearchTest(t, &queries)
+		searchIn = []string{"opz"}
+
+		_, err := runManageCmd(runSearch, "", "deploy")
+		if err == nil || !strings.Contains(err.Error(), "opz") {
+			t.Errorf("expected unknown channel error, got: %v", err)
+		}
+		if len(queries) != 0 {
+			t.Errorf("expected no search request, got: %q", queries)
+		}
+	})
+
+	t.Run("should reject contradictory flags", func(t *testing.T) {
+		var queries []url.Values
+		setupSearchTest(t, &queries)
+		searchOn = "2024-05-01"
+		searchBefore = "2024-06-01"
+
+		_, err := runManageCmd(runSearch, "", "deploy")
+		if err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+			t.Errorf("expected validation error, got: %v", err)
+		}
+	})
+
+	t.Run("should reject invalid dates", func(t *testing.T) {
+		var queries []url.Values
+		setupSearchTest(t, &queries)
+		searchAfter = "last tuesday"
+
+		_, err := runManageCmd(runSearch, "", "deploy")
+		if err == nil || !strings.Contains(err.Error(), "invalid --after") {
+			t.Errorf("expected date error, got: %v", err)
+		}
+	})
+}
+
+func TestSearchSortAndCount(t *testing.T) {
+	t.Run("should pass sort and direction", func(t *testing.T) {
+		var queries []url.Values
+		setupSearchTest(t, &queries)
+		searchSort = "score"
+		searchAsc = true
+
+		if _, err := runManageCmd(runSearch, "", "deploy"); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(queries) != 1 || queries[0].Get("sort") != "score" || queries[0].Get("sort_dir") != "asc" {
+			t.Errorf("unexpected queries: %v", queries)
+		}
+	})
+
+	t.Run("should fetch all matches", func(t *testing.T) {
+		var queries []url.Values
+		setupSearchTest(t, &queries)
+		searchCount = "all"
+
+		output, err := runManageCmd(runSearch, "", "deploy")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, "Found 1 messages") || queries[0].Get("sort") != "timestamp" || queries[0].Get("sort_dir") != "desc" {
+			t.Errorf("unexpected output: %s (%v)", output, queries)
+		}
+	})
+
+	t.Run("should reject invalid combinations", func(t *testing.T) {
+		tests := []struct {
+			count string
+			sort  string
+			want  string
+		}{
+			{"0", "timestamp", "positive number or 'all'"},
+			{"many", "timestamp", "positive number or 'all'"},
+			{"20", "relevance", "invalid sort"},
+			{"all", "score", "--count all requires --sort timestamp"},
+		}
+		for _, tt := range tests {
+			var queries []url.Values
+			setupSearchTest(t, &queries)
+			searchCount, searchSort = tt.count, tt.sort
+
+			_, err := runManageCmd(runSearch, "", "deploy")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q for --count %s --sort %s, got: %v", tt.want, tt.count, tt.sort, err)
+			}
+		}
+	})
+}
+
+func TestSearchFilesCmd(t *testing.T) {
+	t.Run("should search files with filters", func(t *testing.T) {
+		var queries []url.Values
+		setupSearchTest(t, &queries)
+		searchIn = []string{"ops"}
+
+		output, err := runManageCmd(runSearchFiles, "", "diagram")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if len(queries) != 1 || queries[0].Get("query") != "diagram in:#ops" {
+			t.Errorf("unexpected queries: %v", queries)
+		}
+		for _, want := range []string{"Found 1 files containing 'diagram in:#ops'", "Name: diagram.png\n", "Title: Deploy diagram\n", "Type: png, 2.0 KB\n", "User: U000ALICE\n", "Channels: C0000OPS\n"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("expected %q in output:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("should support file placeholders", func(t *testing.T) {
+		var queries []url.Values
+		setupSearchTest(t, &queries)
+		searchFormat = "{name} ({filetype}, {size}) by {user_id} in {channels}: {permalink}"
+
+		output, err := runManageCmd(runSearchFiles, "", "diagram")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if output != "diagram.png (png, 2.0 KB) by U000ALICE in C0000OPS: https://example.slack.com/files/F0000001\n" {
+			t.Errorf("unexpected output: %q", output)
+		}
+	})
+}
+
+func TestSearchAllCmd(t *testing.T) {
+	t.Run("should print messages and files", func(t *testing.T) {
+		var queries []url.Values
+		setupSearchTest(t, &queries)
+
+		output, err := runManageCmd(runSearchAll, "", "deploy")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		for _, want := range []string{"Found 1 messages and 1 files containing 'deploy'", "Messages:\n\nChannel: #ops\n", "Timestamp: 1714521600.000100\nTime: ", "Files:\n\nName: diagram.png\n"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("expected %q in output:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("should print JSON", func(t *testing.T) {
+		var queries []url.Values
+		setupSearchTest(t, &queries)
+		searchFormat = "json"
+
+		output, err := runManageCmd(runSearchAll, "", "deploy")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		var result struct {
+			Messages struct{ Total int } `json:"messages"`
+			Files    struct{ Total int } `json:"files"`
+			Query    string              `json:"query"`
+		}
+		if err := json.Unmarshal([]byte(output), &result); err != nil || result.Messages.Total != 1 || result.Files.Total != 1 || result.Query != "deploy" {
+			t.Errorf("unexpected output: %s (%v)", output, err)
+		}
+	})
+
+	t.Run("should reject --count all", func(t *testing.T) {
+		var queries []url.Values
+		setupSearchTest(t, &queries)
+		searchCount = "all"
+
+		if _, err := runManageCmd(runSearchAll, "", "deploy"); err == nil || len(queries) != 0 {
+			t.Errorf("expected error without searching, got: %v", err)
+		}
+	})
+}
+
+func TestSearchFormat(t *testing.T) {
+	t.Run("should render templates", func(t *testing.T) {
+		var queries []url.Values
+		setupSearchTest(t, &queries)
+		searchFormat = `{{.channel.name}} {{upper .username}}: {{truncate 8 .text}} [{{.thread_ts}}]`
+
+		output, err := runManageCmd(runSearch, "", "deploy")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if output != "ops ALICE: deploy … []\n" {
+			t.Errorf("unexpected output: %q", output)
+		}
+	})
+
+	t.Run("should reject invalid templates before searching", func(t *testing.T) {
+		var queries []url.Values
+		setupSearchTest(t, &queries)
+		searchFormat = `{{.text`
+
+		_, err := runManageCmd(runSearch, "", "deploy")
+		if err == nil || !strings.Contains(err.Error(), "invalid format") || len(queries) != 0 {
+			t.Errorf("expected format error without searching, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/oppai/slakctl/internal/printer"
+	"github.com/oppai/slakctl/internal/snapshot"
+	"github.com/oppai/slakctl/internal/timefmt"
+	"github.com/oppai/slakctl/pkg/slack"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotDir      string
+	snapshotProgress bool
+	diffOutput       string
+)
+
+var channelSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save the channel directory to a file",
+	Long:  "Save every public and private channel, including archived ones, to a timestamped JSON file such as channels-20240501-093000.000.json. Compare snapshots with 'slakctl channel diff'.",
+	Args:  cobra.NoArgs,
+	RunE:  runChannelSnapshot,
+}
+
+var channelDiffCmd = &cobra.Command{
+	Use:   "diff <old-snapshot> [new-snapshot]",
+	Short: "Compare channel snapshots",
+	Long:  "Report channels added, removed and changed between two snapshots. Channels are tracked by ID, so renames are reported as changes. With one snapshot, it is compared with the current state of the workspace.\n\nCompared attributes are the name, archived state, privacy, sharing, topic and purpose.",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE:  runChannelDiff,
+}
+
+func init() {
+	channelSnapshotCmd.Flags().StringVarP(&snapshotDir, "dir", "d", ".", "Directory to write the snapshot to")
+	channelSnapshotCmd.Flags().BoolVarP(&snapshotProgress, "progress", "p", true, "Show progress")
+	channelDiffCmd.Flags().StringVarP(&diffOutput, "output", "o", "text", "Output format: text|"+printer.Formats)
+	channelCmd.AddCommand(channelSnapshotCmd)
+	channelCmd.AddCommand(channelDiffCmd)
+}
+
+func runChannelSnapshot(cmd *cobra.Command, args []string) error {
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	s, err := takeSnapshot(cmd, client)
+	if err != nil {
+		return err
+	}
+
+	path, err := snapshot.Save(snapshotDir, s)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Saved %d channels to %s\n", len(s.Channels), path)
+	return nil
+}
+
+// takeSnapshot fetches the channel directory, bypassing the cache so that
+// the snapshot reflects the workspace right now.
+func takeSnapshot(cmd *cobra.Command, client *slack.Client) (*snapshot.Snapshot, error) {
+	identity, err := client.AuthTestContext(cmd.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	options := slack.ListChannelsOptions{
+		AllChannels:     true,
+		IncludeArchived: true,
+		Types:           []string{"public_channel", "private_channel"},
+	}
+	if snapshotProgress {
+		options.ProgressFunc = func(current, total int) {
+			cmd.PrintErrf("\rFetched %d channels", current)
+		}
+	}
+
+	takenAt := time.Now()
+	channels, err := slack.Collect(client.Channels(cmd.Context(), options))
+	if snapshotProgress {
+		cmd.PrintErrln()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channels: %w", err)
+	}
+
+	return &snapshot.Snapshot{TakenAt: takenAt, TeamID: identity.TeamID, Team: identity.Team, Channels: channels}, nil
+}
+
+func runChannelDiff(cmd *cobra.Command, args []string) error {
+	var format printer.Format
+	if diffOutput != "text" {
+		var err error
+		if format, err = printer.ParseFormat(diffOutput); err != nil {
+			return err
+		}
+	}
+
+	older, err := snapshot.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	var newer *snapshot.Snapshot
+	if len(args) == 2 {
+		if newer, err = snapshot.Load(args[1]); err != nil {
+			return err
+		}
+	} else {
+		client, err := authenticatedClient()
+		if err != nil {
+			return err
+		}
+		if newer, err = takeSnapshot(cmd, client); err != nil {
+			return err
+		}
+	}
+
+	// チーム名は変更できるので ID で比べる
+	if older.TeamID != "" && newer.TeamID != "" && older.TeamID != newer.TeamID {
+		cmd.PrintErrf("Warning: comparing snapshots of different workspaces (%s and %s)\n", teamLabel(older), teamLabel(newer))
+	}
+
+	changes := snapshot.Diff(older, newer)
+
+	if format.Kind != "" {
+		return printer.Print(cmd.OutOrStdout(), format, changes, changeColumns)
+	}
+
+	cmd.Printf("Comparing %s with %s\n\n", timefmt.Default.Format(older.TakenAt), timefmt.Default.Format(newer.TakenAt))
+	if len(changes) == 0 {
+		cmd.Println("No changes")
+		return nil
+	}
+
+	counts := map[snapshot.ChangeType]int{}
+	for _, change := range changes {
+		counts[change.Type]++
+		printChange(cmd, change)
+	}
+	cmd.Printf("\n%d added, %d removed, %d changed\n", counts[snapshot.Added], counts[snapshot.Removed], counts[snapshot.Changed])
+	return nil
+}
+
+func printChange(cmd *cobra.Command, change snapshot.Change) {
+	switch change.Type {
+	case snapshot.Added:
+		cmd.Printf("+ #%s (%s)", change.Name, change.ID)
+		if change.Channel.Created != 0 {
+			cmd.Printf(" created %s", timefmt.Default.In(change.Channel.CreatedTime()).Format(time.DateOnly))
+		}
+		if change.Channel.IsPrivate {
+			cmd.Print(", private")
+		}
+		if change.Channel.IsExtShared {
+			cmd.Print(", externally shared")
+		}
+		cmd.Println()
+
+	case snapshot.Removed:
+		cmd.Printf("- #%s (%s)\n", change.Name, change.ID)
+
+	case snapshot.Changed:
+		cmd.Printf("~ #%s (%s)", change.Name, change.ID)
+		if change.OldName != "" {
+			cmd.Printf(" renamed from #%s", change.OldName)
+		}
+		cmd.Println()
+		for _, field := range change.Fields {
+			if field.Field == "name" {
+				continue
+			}
+			cmd.Printf("    %s: %q -> %q\n", field.Field, field.Old, field.New)
+		}
+	}
+}
+
+// changeColumns are the columns of channel diff -o table, wide, csv and
+// tsv.
+var changeColumns = []printer.Column[snapshot.Change]{
+	{Header: "CHANGE", Value: func(c snapshot.Change) string { return string(c.Type) }},
+	{Header: "ID", Value: func(c snapshot.Change) string { return c.ID }},
+	{Header: "NAME", Value: func(c snapshot.Change) string { return "#" + c.Name }},
+	{Header: "OLD NAME", Value: func(c snapshot.Change) string {
+		if c.OldName == "" {
+			return ""
+		}
+		return "#" + c.OldName
+	}},
+	{Header: "FIELDS", Value: func(c snapshot.Change) string {
+		var fields []string
+		for _, field := range c.Fields {
+			fields = append(fields, fmt.Sprintf("%s: %s -> %s", field.Field, field.Old, field.New))
+		}
+		return strings.Join(fields, "; ")
+	}},
+}
+
+// teamLabel names the workspace of s in messages.
+func teamLabel(s *snapshot.Snapshot) string {
+	if s.Team == "" {
+		return s.TeamID
+	}
+	return s.Team + " (" + s.TeamID + ")"
+}
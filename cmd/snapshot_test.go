@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oppai/slakctl/internal/snapshot"
+	"github.com/oppai/slakctl/pkg/slack"
+)
+
+func TestChannelSnapshotCmd(t *testing.T) {
+	tempDir := newTestWorkspace(t, func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{"ok": true}
+		switch r.URL.Path {
+		case "/api/auth.test":
+			response["team"] = "Acme"
+			response["team_id"] = "T1"
+		case "/api/conversations.list":
+			if r.URL.Query().Get("exclude_archived") != "" || r.URL.Query().Get("types") != "public_channel,private_channel" {
+				t.Errorf("expected archived and private channels to be included, got: %s", r.URL.RawQuery)
+			}
+			response["channels"] = []map[string]interface{}{
+				{"id": "C1", "name": "general"},
+				{"id": "C2", "name": "secret", "is_private": true},
+			}
+		}
+		json.NewEncoder(w).Encode(response)
+	})
+
+	snapshotDir = filepath.Join(tempDir, "snapshots")
+	snapshotProgress = false
+	defer func() { snapshotDir = "." }()
+
+	output, err := runManageCmd(runChannelSnapshot, "")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !strings.HasPrefix(output, "Saved 2 channels to "+filepath.Join(snapshotDir, "channels-")) {
+		t.Errorf("unexpected output: %s", output)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(snapshotDir, "channels-*.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 snapshot file, got: %v", files)
+	}
+	s, err := snapshot.Load(files[0])
+	if err != nil || s.TeamID != "T1" || s.Team != "Acme" || len(s.Channels) != 2 {
+		t.Errorf("unexpected snapshot: %+v (%v)", s, err)
+	}
+}
+
+func TestChannelDiffCmd(t *testing.T) {
+	dir := t.TempDir()
+	older, _ := snapshot.Save(dir, &snapshot.Snapshot{
+		TakenAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Channels: []slack.Channel{
+			{ID: "C1", Name: "proj-x"},
+			{ID: "C2", Name: "partners"},
+			{ID: "C3", Name: "gone"},
+		},
+	})
+	newer, _ := snapshot.Save(dir, &snapshot.Snapshot{
+		TakenAt: time.Date(2024, 5, 8, 0, 0, 0, 0, time.UTC),
+		Channels: []slack.Channel{
+			{ID: "C1", Name: "proj-y", IsArchived: true},
+			{ID: "C2", Name: "partners", IsExtShared: true},
+			{ID: "C4", Name: "new", IsPrivate: true},
+		},
+	})
+
+	t.Run("should print changes as text", func(t *testing.T) {
+		output, err := runManageCmd(runChannelDiff, "", older, newer)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		for _, want := range []string{
+			"+ #new (C4), private\n",
+			"- #gone (C3)\n",
+			"~ #proj-y (C1) renamed from #proj-x\n    is_archived: \"false\" -> \"true\"\n",
+			"~ #partners (C2)\n    is_ext_shared: \"false\" -> \"true\"\n",
+			"1 added, 1 removed, 2 changed\n",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("expected %q in output:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("should support output formats", func(t *testing.T) {
+		diffOutput = "json"
+		defer func() { diffOutput = "text" }()
+
+		output, err := runManageCmd(runChannelDiff, "", older, newer)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		var changes []snapshot.Change
+		if err := json.Unmarshal([]byte(output), &changes); err != nil {
+			t.Fatalf("expected JSON output, got: %s", output)
+		}
+		if len(changes) != 4 || changes[3].OldName != "proj-x" {
+			t.Errorf("unexpected changes: %+v", changes)
+		}
+	})
+
+	t.Run("should warn about snapshots of different workspaces", func(t *testing.T) {
+		other, _ := snapshot.Save(dir, &snapshot.Snapshot{TakenAt: time.Date(2024, 5, 9, 0, 0, 0, 0, time.UTC), TeamID: "T2", Team: "Acme"})
+		acme, _ := snapshot.Save(dir, &snapshot.Snapshot{TakenAt: time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC), TeamID: "T1", Team: "Acme"})
+
+		output, err := runManageCmd(runChannelDiff, "", other, acme)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, "Warning: comparing snapshots of different workspaces (Acme (T2) and Acme (T1))") {
+			t.Errorf("expected warning, got: %s", output)
+		}
+	})
+
+	t.Run("should not warn when the workspace was renamed", func(t *testing.T) {
+		before, _ := snapshot.Save(dir, &snapshot.Snapshot{TakenAt: time.Date(2024, 5, 11, 0, 0, 0, 0, time.UTC), TeamID: "T1", Team: "Acme"})
+		after, _ := snapshot.Save(dir, &snapshot.Snapshot{TakenAt: time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC), TeamID: "T1", Team: "Acme Corp"})
+
+		output, err := runManageCmd(runChannelDiff, "", before, after)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if strings.Contains(output, "Warning") {
+			t.Errorf("expected no warning, got: %s", output)
+		}
+	})
+
+	t.Run("should fail on unreadable snapshots", func(t *testing.T) {
+		bad := filepath.Join(dir, "bad.json")
+		os.WriteFile(bad, []byte("not json"), 0644)
+
+		if _, err := runManageCmd(runChannelDiff, "", bad, newer); err == nil {
+			t.Error("expected error for invalid snapshot")
+		}
+	})
+}
@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/oppai/slakctl/internal/batch"
+	"github.com/oppai/slakctl/internal/printer"
+	"github.com/oppai/slakctl/internal/timefmt"
+	"github.com/oppai/slakctl/pkg/slack"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	staleDays        int
+	staleTypes       string
+	staleMatch       string
+	staleMinMembers  int
+	staleConcurrency int
+	staleProgress    bool
+	staleOutput      string
+)
+
+var channelStaleCmd = &cobra.Command{
+	Use:   "stale",
+	Short: "List channels without recent messages",
+	Long:  "List channels whose most recent message is older than --days days, oldest first. Channels without any messages are listed once they are older than --days, or with unknown idle days when Slack does not report when they were created.\n\nThe latest message of every channel is fetched with conversations.history, a few channels at a time (--concurrency) within Slack's rate limits. Archived channels are not included. The first column of -o csv is the channel ID, so the output can be passed to 'slakctl channel archive --from-file'.",
+	Args:  cobra.NoArgs,
+	RunE:  runChannelStale,
+}
+
+func init() {
+	channelStaleCmd.Flags().IntVar(&staleDays, "days", 90, "Report channels without messages in this many days")
+	channelStaleCmd.Flags().StringVarP(&staleTypes, "types", "t", "public", "Comma-separated conversation types: public, private, im, mpim")
+	channelStaleCmd.Flags().StringVar(&staleMatch, "match", "", "Only check channels whose name matches a glob (inc-*) or /regexp/")
+	channelStaleCmd.Flags().IntVar(&staleMinMembers, "min-members", 0, "Only check channels with at least this many members")
+	channelStaleCmd.Flags().IntVar(&staleConcurrency, "concurrency", 4, "Number of channels checked at once")
+	channelStaleCmd.Flags().BoolVarP(&staleProgress, "progress", "p", true, "Show progress")
+	channelStaleCmd.Flags().StringVarP(&staleOutput, "output", "o", "table", "Output format: "+printer.Formats)
+	channelCmd.AddCommand(channelStaleCmd)
+}
+
+// staleChannel is a row of channel stale output.
+type staleChannel struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	NumMembers    int    `json:"num_members"`
+	Created       int64  `json:"created"`
+	LastMessageTS string `json:"last_message_ts,omitempty"`
+	LastMessageAt string `json:"last_message_at,omitempty"`
+	LastPosterID  string `json:"last_poster_id,omitempty"`
+	LastPoster    string `json:"last_poster,omitempty"`
+	// IdleDays is nil for channels without messages whose creation time
+	// is unknown.
+	IdleDays *int `json:"idle_days"`
+}
+
+func runChannelStale(cmd *cobra.Command, args []string) error {
+	if staleDays < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+	if staleConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	if staleMinMembers < 0 {
+		return fmt.Errorf("--min-members must not be negative")
+	}
+
+	format, err := printer.ParseFormat(staleOutput)
+	if err != nil {
+		return err
+	}
+	types, err := parseChannelTypes(staleTypes)
+	if err != nil {
+		return err
+	}
+	filter := slack.ChannelFilter{Match: staleMatch, MinMembers: staleMinMembers}
+	if _, err := filter.Matcher(); err != nil {
+		return err
+	}
+
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	cutoff := now.AddDate(0, 0, -staleDays)
+
+	channels, err := client.ListChannelsContext(cmd.Context(), slack.ListChannelsOptions{
+		AllChannels: true,
+		Types:       types,
+		Filter:      filter,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list channels: %w", err)
+	}
+
+	// 期間内に作られたチャンネルは古くなりようがないので調べない
+	var candidates []slack.Channel
+	for _, ch := range channels {
+		if ch.Created == 0 || ch.CreatedTime().Before(cutoff) {
+			candidates = append(candidates, ch)
+		}
+	}
+
+	users, err := client.ListUsersContext(cmd.Context())
+	if err != nil {
+		if cmd.Context().Err() != nil {
+			return err
+		}
+		// 投稿者名は表示のためだけなので ID のまま続ける
+		cmd.PrintErrf("Warning: could not list users, last posters are shown by ID: %v\n", err)
+	}
+	names := make(map[string]string, len(users))
+	for _, user := range users {
+		names[user.ID] = user.Name
+	}
+
+	var progress func(done int)
+	if staleProgress {
+		progress = func(done int) {
+			cmd.PrintErrf("\rChecked %d/%d channels", done, len(candidates))
+		}
+	}
+
+	latest, err := latestMessages(cmd.Context(), client, candidates, staleConcurrency, progress, func(ch slack.Channel, message string) {
+		cmd.PrintErrf("\rSkipping #%s: %s\n", ch.Name, message)
+	})
+	if staleProgress && len(candidates) > 0 {
+		cmd.PrintErrln()
+	}
+	if err != nil {
+		return err
+	}
+
+	var stale []staleChannel
+	for i, ch := range candidates {
+		message, ok := latest[i]
+		if !ok {
+			continue
+		}
+
+		if ch.IsIM {
+			ch.Name = ch.User
+		}
+		row := staleChannel{
+			ID:         ch.ID,
+			Name:       ch.Name,
+			Type:       ch.Type(),
+			NumMembers: ch.NumMembers,
+			Created:    ch.Created,
+		}
+
+		var last time.Time
+		if ch.Created != 0 {
+			last = ch.CreatedTime()
+		}
+		if message != nil {
+			t, err := slack.ParseTimestamp(message.TS)
+			if err != nil {
+				return err
+			}
+			last = t
+			row.LastMessageTS = message.TS
+			row.LastMessageAt = timefmt.Default.In(t).Format(time.RFC3339)
+			row.LastPosterID = message.User
+			row.LastPoster = posterName(*message, names)
+		}
+		if !last.IsZero() {
+			if !last.Before(cutoff) {
+				continue
+			}
+			idle := int(now.Sub(last).Hours() / 24)
+			row.IdleDays = &idle
+		}
+		stale = append(stale, row)
+	}
+
+	// 作成日時もわからないチャンネルは最後に並べる
+	sort.SliceStable(stale, func(i, j int) bool {
+		if stale[i].IdleDays == nil || stale[j].IdleDays == nil {
+			return stale[j].IdleDays == nil && stale[i].IdleDays != nil
+		}
+		return *stale[i].IdleDays > *stale[j].IdleDays
+	})
+
+	if format.Kind == printer.Table && len(stale) == 0 {
+		cmd.PrintErrf("No channels without messages in the last %d days\n", staleDays)
+		return nil
+	}
+	return printer.Print(cmd.OutOrStdout(), format, stale, staleColumns)
+}
+
+// latestMessages fetches the latest message of every channel with at most
+// workers requests in flight. The result maps the index of a channel to
+// its latest message, or to nil for channels without messages. Channels
+// that cannot be read are passed to warn and left out.
+func latestMessages(ctx context.Context, client *slack.Client, channels []slack.Channel, workers int, progress func(done int), warn func(slack.Channel, string)) (map[int]*slack.Message, error) {
+	ids := make([]string, len(channels))
+	indexes := make(map[string]int, len(channels))
+	for i, ch := range channels {
+		ids[i] = ch.ID
+		indexes[ch.ID] = i
+	}
+
+	// 各ワーカーは自分のチャンネルの位置にだけ書き込む
+	messages := make([]*slack.Message, len(channels))
+	done := 0
+	results := batch.Run(ctx, ids, workers, func(ctx context.Context, id string) batch.Result {
+		message, err := client.LatestMessage(ctx, id)
+		if err != nil {
+			return batch.Result{Status: batch.StatusFailed, Message: err.Error()}
+		}
+		messages[indexes[id]] = message
+		return batch.Result{Status: batch.StatusOK}
+	}, func(result batch.Result) {
+		done++
+		if result.Status == batch.StatusFailed && ctx.Err() == nil {
+			warn(channels[indexes[result.Input]], result.Message)
+		}
+		if progress != nil {
+			progress(done)
+		}
+	})
+
+	latest := make(map[int]*slack.Message, len(channels))
+	for i, result := range results {
+		if result.Status == batch.StatusOK {
+			latest[i] = messages[i]
+		}
+	}
+	return latest, ctx.Err()
+}
+
+// posterName returns the name shown for the author of message.
+func posterName(message slack.Message, names map[string]string) string {
+	if name, ok := names[message.User]; ok {
+		return name
+	}
+	if message.Username != "" {
+		return message.Username
+	}
+	if message.User != "" {
+		return message.User
+	}
+	return message.BotID
+}
+
+// staleColumns are the columns of channel stale -o table, wide, csv and
+// tsv.
+var staleColumns = []printer.Column[staleChannel]{
+	{Header: "ID", Value: func(ch staleChannel) string { return ch.ID }},
+	{Header: "NAME", Value: func(ch staleChannel) string {
+		if ch.Type == "im" {
+			return "@" + ch.Name
+		}
+		return "#" + ch.Name
+	}},
+	{Header: "MEMBERS", Value: func(ch staleChannel) string { return strconv.Itoa(ch.NumMembers) }},
+	{Header: "LAST MESSAGE", Value: func(ch staleChannel) string {
+		if ch.LastMessageTS == "" {
+			return "never"
+		}
+		return timefmt.Default.FormatTS(ch.LastMessageTS)
+	}},
+	{Header: "IDLE DAYS", Value: func(ch staleChannel) string {
+		if ch.IdleDays == nil {
+			return "unknown"
+		}
+		return strconv.Itoa(*ch.IdleDays)
+	}},
+	{Header: "LAST POSTER", Value: func(ch staleChannel) string { return ch.LastPoster }},
+	{Header: "TYPE", Wide: true, Value: func(ch staleChannel) string { return ch.Type }},
+	{Header: "CREATED", Wide: true, Value: func(ch staleChannel) string {
+		return timefmt.Default.FormatUnix(ch.Created)
+	}},
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oppai/slakctl/internal/timefmt"
+)
+
+// setupStaleTest は listUsers が false のとき users.list が失敗するワークスペースを用意する
+func setupStaleTest(t *testing.T, listUsers bool) *[]string {
+	now := time.Now()
+	daysAgo := func(days int) int64 { return now.AddDate(0, 0, -days).Unix() }
+
+	var checked []string
+	newFakeSlack(t, slackMethods{
+		"conversations.list": respond(map[string]interface{}{
+			"channels": []map[string]interface{}{
+				{"id": "CQUIET01", "name": "quiet", "num_members": 12, "created": daysAgo(400)},
+				{"id": "CBUSY001", "name": "busy", "num_members": 40, "created": daysAgo(400)},
+				{"id": "CNEW0001", "name": "new", "num_members": 2, "created": daysAgo(10)},
+				{"id": "CEMPTY01", "name": "empty", "num_members": 1, "created": daysAgo(200)},
+				{"id": "CSECRET1", "name": "locked", "num_members": 3, "created": daysAgo(300)},
+				{"id": "CLEGACY1", "name": "legacy", "num_members": 1},
+			},
+		}),
+		"conversations.history": func(r *http.Request) map[string]interface{} {
+			channel := r.URL.Query().Get("channel")
+			checked = append(checked, channel)
+
+			switch channel {
+			case "CQUIET01":
+				return map[string]interface{}{"messages": []map[string]interface{}{{"user": "U1", "ts": fmt.Sprintf("%d.000100", daysAgo(120))}}}
+			case "CBUSY001":
+				return map[string]interface{}{"messages": []map[string]interface{}{{"user": "U2", "ts": fmt.Sprintf("%d.000100", daysAgo(1))}}}
+			case "CEMPTY01", "CLEGACY1":
+				return map[string]interface{}{"messages": []map[string]interface{}{}}
+			}
+			return map[string]interface{}{"ok": false, "error": "not_in_channel"}
+		},
+		"users.list": func(*http.Request) map[string]interface{} {
+			if !listUsers {
+				return map[string]interface{}{"ok": false, "error": "missing_scope"}
+			}
+			return map[string]interface{}{"members": []map[string]interface{}{{"id": "U1", "name": "alice"}, {"id": "U2", "name": "bob"}}}
+		},
+	})
+
+	staleDays, staleConcurrency, staleProgress, staleTypes = 90, 2, false, "public"
+	t.Cleanup(func() { staleOutput = "table" })
+
+	return &checked
+}
+
+func TestChannelStaleCmd(t *testing.T) {
+	t.Run("should list channels idle for longer than --days", func(t *testing.T) {
+		checked := setupStaleTest(t, true)
+		staleOutput = "table"
+
+		output, err := runManageCmd(runChannelStale, "")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		if !strings.Contains(lines[0], "Skipping #locked: ") {
+			t.Errorf("expected unreadable channel to be reported, got: %s", lines[0])
+		}
+		rows := lines[1:]
+		if len(rows) != 4 || !strings.HasPrefix(rows[0], "ID") {
+			t.Fatalf("expected header and 3 channels, got:\n%s", output)
+		}
+		// 最も長く動きのないチャンネルが先に来る
+		if !strings.Contains(rows[1], "#empty") || !strings.Contains(rows[1], "never") {
+			t.Errorf("expected #empty first, got: %s", rows[1])
+		}
+		if !strings.Contains(rows[2], "#quiet") || !strings.Contains(rows[2], "120") || !strings.Contains(rows[2], "alice") || !strings.Contains(rows[2], "12") {
+			t.Errorf("unexpected row for #quiet: %s", rows[2])
+		}
+		// 作成日時のないチャンネルは 1970 年からではなく不明として最後に並ぶ
+		if !strings.Contains(rows[3], "#legacy") || !strings.Contains(rows[3], "unknown") {
+			t.Errorf("expected #legacy last with unknown idle days, got: %s", rows[3])
+		}
+
+		for _, id := range *checked {
+			if id == "CNEW0001" {
+				t.Error("expected channels created within --days not to be checked")
+			}
+		}
+	})
+
+	t.Run("should write csv that archive --from-file can read", func(t *testing.T) {
+		setupStaleTest(t, true)
+		staleOutput = "csv"
+
+		output, err := runManageCmd(runChannelStale, "")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, "\nID,NAME,MEMBERS,LAST MESSAGE,IDLE DAYS,LAST POSTER,TYPE,CREATED\nCEMPTY01,#empty,") {
+			t.Errorf("unexpected csv:\n%s", output)
+		}
+	})
+
+	t.Run("should print last_message_at in the --tz time zone", func(t *testing.T) {
+		setupStaleTest(t, true)
+		staleOutput = "json"
+		defer func(f timefmt.Formatter) { *timefmt.Default = f }(*timefmt.Default)
+		timefmt.Default.Location = time.FixedZone("JST", 9*60*60)
+
+		output, err := runManageCmd(runChannelStale, "")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		var rows []staleChannel
+		if err := json.Unmarshal([]byte(output[strings.Index(output, "["):]), &rows); err != nil {
+			t.Fatalf("expected JSON output, got: %s", output)
+		}
+		if len(rows) != 3 || !strings.HasSuffix(rows[1].LastMessageAt, "+09:00") || rows[2].IdleDays != nil {
+			t.Errorf("unexpected rows: %+v", rows)
+		}
+	})
+	t.Run("should show last posters by ID when users cannot be listed", func(t *testing.T) {
+		setupStaleTest(t, false)
+		staleOutput = "table"
+
+		output, err := runManageCmd(runChannelStale, "")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !strings.Contains(output, "Warning: could not list users") || !strings.Contains(output, " U1") {
+			t.Errorf("unexpected output:\n%s", output)
+		}
+	})
+}
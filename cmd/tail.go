@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/oppai/slakctl/internal/config"
+	"github.com/oppai/slakctl/internal/printer"
+	"github.com/oppai/slakctl/pkg/slack"
+
+	"github.com/spf13/cobra"
+)
+
+// AppTokenEnv overrides the app-level token used for Socket Mode.
+const AppTokenEnv = "SLAKCTL_APP_TOKEN"
+
+// tailTextFormat is the one-line format used by --format text. Direct
+// messages are shown as @user instead of #channel.
+const tailTextFormat = "[{{time .ts}}] {{if .channel.is_im}}@{{else}}#{{end}}{{or .channel.name .channel.id}} {{or .username .user}}: {{trim .text}}"
+
+var (
+	tailMode     string
+	tailInterval time.Duration
+	tailFormat   string
+)
+
+var channelTailCmd = &cobra.Command{
+	Use:   "tail [channel...]",
+	Short: "Print new messages of channels as they arrive",
+	Long:  "Print new messages posted to one or more channels as they arrive, like tail -f.\n\nWhen an app-level token is configured ('slakctl auth app-token' or SLAKCTL_APP_TOKEN), messages are received in real time over Socket Mode. Otherwise the channels are polled with conversations.history every --interval.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runChannelTail,
+}
+
+func init() {
+	channelTailCmd.Flags().StringVar(&tailMode, "mode", "auto", "How to receive messages: auto, socket, or poll")
+	channelTailCmd.Flags().DurationVar(&tailInterval, "interval", 5*time.Second, "Polling interval when not using Socket Mode")
+	channelTailCmd.Flags().StringVarP(&tailFormat, "format", "f", "text", "Output format: text, json, or a custom format such as '{{.channel.name}}: {{.text}}'")
+	channelCmd.AddCommand(channelTailCmd)
+}
+
+func runChannelTail(cmd *cobra.Command, args []string) error {
+	if tailInterval < time.Second {
+		return fmt.Errorf("interval must be at least 1s")
+	}
+	if err := validateFormat(tailFormat, messagePlaceholders); err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	appToken := os.Getenv(AppTokenEnv)
+	if appToken == "" {
+		appToken = cfg.AppToken
+	}
+
+	useSocket := false
+	switch tailMode {
+	case "auto":
+		useSocket = appToken != ""
+	case "socket":
+		if appToken == "" {
+			return fmt.Errorf("socket mode requires an app-level token. Please run 'slakctl auth app-token' first")
+		}
+		useSocket = true
+	case "poll":
+	default:
+		return fmt.Errorf("invalid mode %q: use auto, socket, or poll", tailMode)
+	}
+
+	client, err := authenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	resolver := slack.NewResolver(client)
+	names, err := tailChannels(cmd, resolver, args)
+	if err != nil {
+		return err
+	}
+	userName := tailUserNames(cmd, resolver)
+
+	printMsg := func(msg slack.Message) {
+		if ref, ok := names[msg.Channel.ID]; ok && msg.Channel.Name == "" {
+			msg.Channel = ref
+		}
+		if msg.Username == "" && msg.User != "" {
+			msg.Username = userName(msg.User)
+		}
+		if err := printTailMessage(cmd, msg, tailFormat); err != nil {
+			cmd.PrintErrln("Error:", err)
+		}
+	}
+
+	if useSocket {
+		cmd.PrintErrf("Watching %d channel(s) via Socket Mode (Ctrl-C to stop)\n", len(names))
+		err := newClient(appToken).RunSocketMode(cmd.Context(), func(event slack.Event) {
+			if event.Type != "message" || !isTailableSubtype(event.Subtype) {
+				return
+			}
+			if _, ok := names[event.Channel]; !ok {
+				return
+			}
+			printMsg(event.Message())
+		})
+		if isCancelled(err) {
+			return nil
+		}
+		return err
+	}
+
+	cmd.PrintErrf("Polling %d channel(s) every %v (Ctrl-C to stop)\n", len(names), tailInterval)
+	err = pollChannels(cmd, client, names, tailInterval, printMsg)
+	if isCancelled(err) {
+		return nil
+	}
+	return err
+}
+
+// tailChannels resolves the channel arguments and maps each conversation
+// ID to the reference messages are shown with.
+func tailChannels(cmd *cobra.Command, resolver *slack.Resolver, args []string) (map[string]slack.ChannelRef, error) {
+	names := make(map[string]slack.ChannelRef, len(args))
+	for _, arg := range args {
+		ref, err := resolveChannelRef(cmd, resolver, arg)
+		if err != nil {
+			return nil, err
+		}
+		names[ref.ID] = ref
+	}
+	return names, nil
+}
+
+// resolveChannelRef resolves a channel argument to the reference messages
+// are shown with: the channel name, or the other user's name for direct
+// messages.
+func resolveChannelRef(cmd *cobra.Command, resolver *slack.Resolver, arg string) (slack.ChannelRef, error) {
+	channel, err := resolver.ResolveChannel(cmd.Context(), arg)
+	if err != nil {
+		return slack.ChannelRef{}, err
+	}
+
+	ref := slack.ChannelRef{ID: channel.ID, Name: channel.Name, IsIM: channel.IsIM}
+	if channel.IsIM {
+		ref.Name = channel.User
+		if user, err := resolver.ResolveUser(cmd.Context(), channel.User); err == nil {
+			ref.Name = user.Name
+		}
+	}
+	return ref, nil
+}
+
+// tailUserNames returns a function that looks up the name a message
+// author is shown with. Each user is looked up once; users that cannot be
+// resolved are shown by ID.
+func tailUserNames(cmd *cobra.Command, resolver *slack.Resolver) func(id string) string {
+	names := map[string]string{}
+	return func(id string) string {
+		if name, ok := names[id]; ok {
+			return name
+		}
+		name := id
+		if user, err := resolver.ResolveUser(cmd.Context(), id); err == nil {
+			name = user.Name
+		}
+		names[id] = name
+		return name
+	}
+}
+
+// pollChannels fetches messages newer than the last one seen in every
+// channel until the command's context is cancelled.
+func pollChannels(cmd *cobra.Command, client *slack.Client, names map[string]slack.ChannelRef, interval time.Duration, printMsg func(slack.Message)) error {
+	ctx := cmd.Context()
+
+	oldest := make(map[string]time.Time, len(names))
+	start := time.Now()
+	for id := range names {
+		oldest[id] = start
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for id := range names {
+			messages, err := client.HistoryContext(ctx, id, slack.HistoryOptions{Oldest: oldest[id]})
+			if err != nil {
+				return err
+			}
+
+			// history は新しい順なので古い順に並べ替えて表示する
+			slices.Reverse(messages)
+			for _, msg := range messages {
+				if !isTailableSubtype(msg.Subtype) {
+					continue
+				}
+				printMsg(msg)
+			}
+
+			if len(messages) > 0 {
+				if t, err := slack.ParseTimestamp(messages[len(messages)-1].TS); err == nil {
+					oldest[id] = t
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// isTailableSubtype reports whether a message subtype represents a new
+// visible message rather than an edit or deletion.
+func isTailableSubtype(subtype string) bool {
+	switch subtype {
+	case "message_changed", "message_deleted", "message_replied":
+		return false
+	}
+	return true
+}
+
+func printTailMessage(cmd *cobra.Command, msg slack.Message, format string) error {
+	if format == "json" {
+		jsonOutput, err := json.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		cmd.Println(string(jsonOutput))
+		return nil
+	}
+
+	if format == "text" {
+		format = tailTextFormat
+	}
+	tmpl, err := printer.ParseTemplate(format, messagePlaceholders)
+	if err != nil {
+		return err
+	}
+	output, err := tmpl.Execute(msg)
+	if err != nil {
+		return err
+	}
+	cmd.Println(output)
+	return nil
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oppai/slakctl/internal/timefmt"
+	"github.com/oppai/slakctl/pkg/slack"
+
+	"github.com/spf13/cobra"
+)
+
+func TestChannelTailCmd(t *testing.T) {
+	t.Run("should reject invalid mode", func(t *testing.T) {
+		tempDir, err := os.MkdirTemp("", "slakctl-test-*")
+		if err != nil {
+			t.Fatalf("failed to create temp dir: %v", err)
+		}
+		defer os.RemoveAll(tempDir)
+
+		originalHome := os.Getenv("HOME")
+		os.Setenv("HOME", tempDir)
+		defer os.Setenv("HOME", originalHome)
+
+		tailMode = "carrier-pigeon"
+		defer func() { tailMode = "auto" }()
+
+		cmd := &cobra.Command{
+			Use:  "tail",
+			RunE: runChannelTail,
+		}
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"C1234567890"})
+
+		err = cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "invalid mode") {
+			t.Errorf("expected invalid mode error, got: %v", err)
+		}
+	})
+
+	t.Run("should require app token for socket mode", func(t *testing.T) {
+		tempDir, err := os.MkdirTemp("", "slakctl-test-*")
+		if err != nil {
+			t.Fatalf("failed to create temp dir: %v", err)
+		}
+		defer os.RemoveAll(tempDir)
+
+		originalHome := os.Getenv("HOME")
+		os.Setenv("HOME", tempDir)
+		defer os.Setenv("HOME", originalHome)
+		t.Setenv(AppTokenEnv, "")
+
+		tailMode = "socket"
+		defer func() { tailMode = "auto" }()
+
+		cmd := &cobra.Command{
+			Use:  "tail",
+			RunE: runChannelTail,
+		}
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"C1234567890"})
+
+		err = cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "app-level token") {
+			t.Errorf("expected app token error, got: %v", err)
+		}
+	})
+}
+
+func TestPollChannels(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("oldest") == "" {
+			t.Error("expected oldest to be set")
+		}
+
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"user": "U1", "text": "second", "ts": "1700000002.000000"},
+				{"user": "U1", "text": "edited", "ts": "1700000001.500000", "subtype": "message_changed"},
+				{"user": "U1", "text": "first", "ts": "1700000001.000000"},
+			},
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := slack.NewClient("test-token",
+		slack.WithBaseURL(server.URL+"/api"),
+		slack.WithTransport(server.Client().Transport),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(ctx)
+
+	var texts []string
+	err := pollChannels(cmd, client, map[string]slack.ChannelRef{"C1234567890": {ID: "C1234567890", Name: "general"}}, time.Second, func(msg slack.Message) {
+		texts = append(texts, msg.Text)
+		if len(texts) == 2 {
+			cancel()
+		}
+	})
+
+	if !isCancelled(err) {
+		t.Fatalf("expected cancellation, got: %v", err)
+	}
+	if strings.Join(texts, ",") != "first,second" {
+		t.Errorf("expected messages oldest first without edits, got: %v", texts)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got: %d", requests)
+	}
+}
+
+func TestPrintTailMessage(t *testing.T) {
+	cmd := &cobra.Command{}
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+
+	defer func() { timefmt.Default = &timefmt.Formatter{} }()
+
+	msg := slack.Message{User: "U1", Text: "hello", TS: "1700000001.000000", Channel: slack.ChannelRef{ID: "C1", Name: "general"}}
+
+	tests := []struct {
+		tz, layout string
+		want       string
+	}{
+		{"UTC", "", "[2023-11-14 22:13:21] #general U1: hello\n"},
+		{"+09:00", "iso", "[2023-11-15T07:13:21+09:00] #general U1: hello\n"},
+		{"UTC", "unix", "[1700000001.000000] #general U1: hello\n"},
+	}
+	for _, test := range tests {
+		buf.Reset()
+		if err := timefmt.Default.Configure(test.tz, test.layout); err != nil {
+			t.Fatalf("failed to configure time format: %v", err)
+		}
+		printTailMessage(cmd, msg, "text")
+
+		if output := buf.String(); output != test.want {
+			t.Errorf("--tz %s --time-format %s: unexpected output: %q", test.tz, test.layout, output)
+		}
+	}
+
+	t.Run("should show direct messages as @user", func(t *testing.T) {
+		buf.Reset()
+		dm := msg
+		dm.Channel = slack.ChannelRef{ID: "D1", Name: "alice", IsIM: true}
+		printTailMessage(cmd, dm, "text")
+
+		if output := buf.String(); output != "[1700000001.000000] @alice U1: hello\n" {
+			t.Errorf("unexpected output: %q", output)
+		}
+	})
+}
+
+func TestTailChannels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{"ok": true}
+		switch r.URL.Path {
+		case "/api/conversations.list":
+			response["channels"] = []map[string]interface{}{{"id": "C1000001", "name": "general"}}
+		case "/api/conversations.info":
+			response["channel"] = map[string]interface{}{"id": "D1000001", "is_im": true, "user": "U1000001"}
+		case "/api/users.info":
+			response["user"] = map[string]interface{}{"id": "U1000001", "name": "alice"}
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := slack.NewClient("test-token", slack.WithBaseURL(server.URL+"/api"), slack.WithRateLimiter(nil))
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	names, err := tailChannels(cmd, slack.NewResolver(client), []string{"C1000001", "D1000001"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := map[string]slack.ChannelRef{
+		"C1000001": {ID: "C1000001", Name: "general"},
+		"D1000001": {ID: "D1000001", Name: "alice", IsIM: true},
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("unexpected names: %+v", names)
+	}
+}
+
+func TestTailUserNames(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("user") != "U1000001" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "user_not_found"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "user": map[string]interface{}{"id": "U1000001", "name": "alice"}})
+	}))
+	defer server.Close()
+
+	client := slack.NewClient("test-token", slack.WithBaseURL(server.URL+"/api"), slack.WithRateLimiter(nil), slack.WithMaxRetries(0))
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	userName := tailUserNames(cmd, slack.NewResolver(client))
+	got := []string{userName("U1000001"), userName("U1000001"), userName("U9999999"), userName("U9999999")}
+
+	if !reflect.DeepEqual(got, []string{"alice", "alice", "U9999999", "U9999999"}) {
+		t.Errorf("unexpected names: %v", got)
+	}
+	if requests != 2 {
+		t.Errorf("expected each user to be looked up once, got %d requests", requests)
+	}
+}
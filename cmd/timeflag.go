@@ -0,0 +1,13 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/oppai/slakctl/internal/timefmt"
+)
+
+// parseTimeFlag parses a time given on the command line with timefmt.Parse.
+// Dates are read in the --tz time zone.
+func parseTimeFlag(value string, now time.Time) (time.Time, error) {
+	return timefmt.Parse(value, timefmt.Default.In(now))
+}
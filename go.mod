@@ -1,14 +1,15 @@
-module slakctl
+module github.com/oppai/slakctl
 
 go 1.24.3
 
 require (
+	github.com/gorilla/websocket v1.5.3
 	github.com/spf13/cobra v1.9.1
 	golang.org/x/oauth2 v0.30.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.6 // indirect
-	golang.org/x/oauth2 v0.30.0 // indirect
 )
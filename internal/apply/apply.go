@@ -0,0 +1,453 @@
+// Package apply manages channels declaratively. A YAML configuration
+// lists the desired channels; Build compares it with the workspace and
+// returns a Plan of the changes needed, which can be reviewed with Write
+// and carried out with Apply.
+package apply
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/oppai/slakctl/pkg/slack"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the desired state read from a configuration file:
+//
+//	channels:
+//	  - name: oncall
+//	    topic: "On call: @alice"
+//	    purpose: Pager rotation and handoffs
+//	    members: [alice, bob]
+//	  - name: old-project
+//	    archived: true
+//
+// Channels that are not listed are left alone.
+type Config struct {
+	Channels []ChannelConfig `yaml:"channels"`
+}
+
+// ChannelConfig is the desired state of one channel, identified by name.
+type ChannelConfig struct {
+	Name string `yaml:"name"`
+	// Private must match existing channels, as Slack does not allow
+	// converting between public and private.
+	Private  bool `yaml:"private"`
+	Archived bool `yaml:"archived"`
+	// Topic and Purpose are left unchanged when omitted. An empty string
+	// clears them.
+	Topic   *string `yaml:"topic"`
+	Purpose *string `yaml:"purpose"`
+	// Members, when given, is the complete member list: missing users are
+	// invited and others are removed. The authenticated user is never
+	// invited or removed, and bots and app users are never removed.
+	Members []string `yaml:"members"`
+}
+
+// Load reads a configuration file.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+	return Parse(data)
+}
+
+// Read reads a configuration from r, such as standard input.
+func Read(r io.Reader) (*Config, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a configuration. Unknown fields are
+// rejected so that typos do not silently leave channels unmanaged.
+func Parse(data []byte) (*Config, error) {
+	var config Config
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+
+	seen := map[string]bool{}
+	for i, channel := range config.Channels {
+		name := strings.TrimPrefix(channel.Name, "#")
+		if name == "" {
+			return nil, fmt.Errorf("channels[%d]: name is required", i)
+		}
+		if err := slack.ValidateChannelName(name); err != nil {
+			return nil, fmt.Errorf("channels[%d]: %w", i, err)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("channels[%d]: #%s is listed more than once", i, name)
+		}
+		seen[name] = true
+		config.Channels[i].Name = name
+	}
+
+	return &config, nil
+}
+
+// Action is what a Change does to a channel.
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+)
+
+// Change is the work needed to bring one channel to its desired state.
+type Change struct {
+	Action  Action
+	Name    string
+	ID      string
+	Private bool
+
+	// Topic and Purpose are set when they change; Old* hold the current
+	// values of updated channels.
+	Topic      *string
+	OldTopic   string
+	Purpose    *string
+	OldPurpose string
+	// Archive and Unarchive change the archived state.
+	Archive   bool
+	Unarchive bool
+
+	Invite []slack.User
+	Kick   []slack.User
+}
+
+// Plan is the list of changes Build found, in configuration order.
+type Plan struct {
+	Changes []*Change
+	// Unchanged counts channels that already match the configuration.
+	Unchanged int
+}
+
+// IsEmpty reports whether the workspace already matches the configuration.
+func (p *Plan) IsEmpty() bool {
+	return len(p.Changes) == 0
+}
+
+// Build compares config with the public and private channels returned by
+// conversations.list and the members returned by conversations.members.
+func Build(ctx context.Context, client *slack.Client, config *Config) (*Plan, error) {
+	identity, err := client.AuthTestContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// キャッシュは古い可能性があるので一覧は毎回取り直す
+	channels, err := slack.Collect(client.Channels(ctx, slack.ListChannelsOptions{
+		AllChannels:     true,
+		IncludeArchived: true,
+		Types:           []string{"public_channel", "private_channel"},
+	}))
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]slack.Channel, len(channels))
+	for _, ch := range channels {
+		existing[ch.Name] = ch
+	}
+
+	resolver := slack.NewResolver(client)
+	plan := &Plan{}
+
+	for _, desired := range config.Channels {
+		members, err := resolveMembers(ctx, resolver, desired.Members, identity.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("#%s: %w", desired.Name, err)
+		}
+
+		current, ok := existing[desired.Name]
+		if !ok {
+			plan.Changes = append(plan.Changes, &Change{
+				Action:  Create,
+				Name:    desired.Name,
+				Private: desired.Private,
+				Topic:   nonEmpty(desired.Topic),
+				Purpose: nonEmpty(desired.Purpose),
+				Archive: desired.Archived,
+				Invite:  members,
+			})
+			continue
+		}
+
+		if current.IsPrivate != desired.Private {
+			return nil, fmt.Errorf("#%s is %s but configured as %s; Slack does not allow converting existing channels", desired.Name, privacy(current.IsPrivate), privacy(desired.Private))
+		}
+
+		change := &Change{
+			Action:     Update,
+			Name:       desired.Name,
+			ID:         current.ID,
+			Private:    current.IsPrivate,
+			OldTopic:   current.Topic.Value,
+			OldPurpose: current.Purpose.Value,
+			Archive:    desired.Archived && !current.IsArchived,
+			Unarchive:  !desired.Archived && current.IsArchived,
+		}
+
+		// アーカイブされたままのチャンネルは変更できないので状態だけを比べる
+		if !(desired.Archived && current.IsArchived) {
+			if desired.Topic != nil && *desired.Topic != current.Topic.Value {
+				change.Topic = desired.Topic
+			}
+			if desired.Purpose != nil && *desired.Purpose != current.Purpose.Value {
+				change.Purpose = desired.Purpose
+			}
+			if desired.Members != nil {
+				change.Invite, change.Kick, err = diffMembers(ctx, client, resolver, current.ID, members, identity.UserID)
+				if err != nil {
+					return nil, fmt.Errorf("#%s: %w", desired.Name, err)
+				}
+			}
+		}
+
+		if change.isNoop() {
+			plan.Unchanged++
+			continue
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	return plan, nil
+}
+
+func (c *Change) isNoop() bool {
+	return c.Topic == nil && c.Purpose == nil && !c.Archive && !c.Unarchive && len(c.Invite) == 0 && len(c.Kick) == 0
+}
+
+// resolveMembers resolves the configured member names, leaving out self.
+func resolveMembers(ctx context.Context, resolver *slack.Resolver, refs []string, self string) ([]slack.User, error) {
+	seen := map[string]bool{self: true}
+	var users []slack.User
+	for _, ref := range refs {
+		user, err := resolver.ResolveUser(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[user.ID] {
+			seen[user.ID] = true
+			users = append(users, *user)
+		}
+	}
+	return users, nil
+}
+
+// slackbotID is the built-in Slackbot, which users.info does not mark as a bot.
+const slackbotID = "USLACKBOT"
+
+// diffMembers compares the desired members with the channel's current
+// members. Removed users are looked up in the user directory so that the
+// plan can show their names, and bots and app users are kept so that an
+// incomplete member list does not remove integrations. A member that
+// cannot be looked up fails the plan rather than being removed.
+func diffMembers(ctx context.Context, client *slack.Client, resolver *slack.Resolver, channelID string, desired []slack.User, self string) (invite, kick []slack.User, err error) {
+	memberIDs, err := client.ListChannelMembers(ctx, channelID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	current := make(map[string]bool, len(memberIDs))
+	for _, id := range memberIDs {
+		current[id] = true
+	}
+
+	wanted := map[string]bool{self: true}
+	for _, user := range desired {
+		wanted[user.ID] = true
+		if !current[user.ID] {
+			invite = append(invite, user)
+		}
+	}
+
+	var directory map[string]slack.User
+	for _, id := range memberIDs {
+		if wanted[id] {
+			continue
+		}
+		if directory == nil {
+			users, err := client.ListUsersContext(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to look up members to remove: %w", err)
+			}
+			directory = make(map[string]slack.User, len(users))
+			for _, user := range users {
+				directory[user.ID] = user
+			}
+		}
+
+		user, ok := directory[id]
+		if !ok {
+			// 他のワークスペースのユーザーは users.list に含まれないので個別に引く
+			info, err := resolver.ResolveUser(ctx, id)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to look up member %s: %w", id, err)
+			}
+			user = *info
+		}
+		if user.IsBot || user.IsAppUser || user.ID == slackbotID {
+			continue
+		}
+		kick = append(kick, user)
+	}
+	sort.Slice(kick, func(i, j int) bool { return kick[i].Name < kick[j].Name })
+
+	return invite, kick, nil
+}
+
+// Apply carries out the plan through the channel methods of client,
+// calling report after every step. It stops at the first error, leaving
+// later changes unapplied; running Build again shows what remains.
+func (p *Plan) Apply(ctx context.Context, client *slack.Client, report func(channel, step string)) error {
+	for _, change := range p.Changes {
+		if err := change.apply(ctx, client, report); err != nil {
+			return fmt.Errorf("#%s: %w", change.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *Change) apply(ctx context.Context, client *slack.Client, report func(channel, step string)) error {
+	if c.Action == Create {
+		channel, err := client.CreateChannel(ctx, c.Name, c.Private)
+		if err != nil {
+			return err
+		}
+		c.ID = channel.ID
+		report(c.Name, fmt.Sprintf("created (%s)", channel.ID))
+	}
+
+	// アーカイブ中は他の変更ができないので、解除を最初に、アーカイブを最後に行う
+	if c.Unarchive {
+		if err := client.UnarchiveChannel(ctx, c.ID); err != nil {
+			return err
+		}
+		report(c.Name, "unarchived")
+	}
+	if c.Topic != nil {
+		if err := client.SetTopic(ctx, c.ID, *c.Topic); err != nil {
+			return err
+		}
+		report(c.Name, "topic set")
+	}
+	if c.Purpose != nil {
+		if err := client.SetPurpose(ctx, c.ID, *c.Purpose); err != nil {
+			return err
+		}
+		report(c.Name, "purpose set")
+	}
+	if len(c.Invite) > 0 {
+		ids := make([]string, len(c.Invite))
+		for i, user := range c.Invite {
+			ids[i] = user.ID
+		}
+		if err := client.InviteToChannel(ctx, c.ID, ids...); err != nil {
+			return err
+		}
+		report(c.Name, "invited "+userNames(c.Invite))
+	}
+	for _, user := range c.Kick {
+		if err := client.KickFromChannel(ctx, c.ID, user.ID); err != nil {
+			return err
+		}
+		report(c.Name, "removed @"+user.Name)
+	}
+	if c.Archive {
+		if err := client.ArchiveChannel(ctx, c.ID); err != nil {
+			return err
+		}
+		report(c.Name, "archived")
+	}
+
+	return nil
+}
+
+// Write prints the plan in the style of terraform plan: "+" for
+// additions, "~" for changes and "-" for removals.
+func (p *Plan) Write(w io.Writer) {
+	if p.IsEmpty() {
+		fmt.Fprintln(w, "No changes. Channels match the configuration.")
+		return
+	}
+
+	created, updated := 0, 0
+	for _, change := range p.Changes {
+		switch change.Action {
+		case Create:
+			created++
+			fmt.Fprintf(w, "  + channel %q (%s)\n", change.Name, privacy(change.Private))
+			if change.Topic != nil {
+				fmt.Fprintf(w, "      + %-8s = %q\n", "topic", *change.Topic)
+			}
+			if change.Purpose != nil {
+				fmt.Fprintf(w, "      + %-8s = %q\n", "purpose", *change.Purpose)
+			}
+			if change.Archive {
+				fmt.Fprintf(w, "      + %-8s = true\n", "archived")
+			}
+
+		case Update:
+			updated++
+			fmt.Fprintf(w, "  ~ channel %q (%s)\n", change.Name, change.ID)
+			if change.Unarchive {
+				fmt.Fprintf(w, "      ~ %-8s = true -> false\n", "archived")
+			}
+			if change.Topic != nil {
+				fmt.Fprintf(w, "      ~ %-8s = %q -> %q\n", "topic", change.OldTopic, *change.Topic)
+			}
+			if change.Purpose != nil {
+				fmt.Fprintf(w, "      ~ %-8s = %q -> %q\n", "purpose", change.OldPurpose, *change.Purpose)
+			}
+			if change.Archive {
+				fmt.Fprintf(w, "      ~ %-8s = false -> true\n", "archived")
+			}
+		}
+
+		for _, user := range change.Invite {
+			fmt.Fprintf(w, "      + %-8s   @%s\n", "member", user.Name)
+		}
+		for _, user := range change.Kick {
+			fmt.Fprintf(w, "      - %-8s   @%s\n", "member", user.Name)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "Plan: %d to create, %d to change, %d unchanged.\n", created, updated, p.Unchanged)
+}
+
+func userNames(users []slack.User) string {
+	names := make([]string, len(users))
+	for i, user := range users {
+		names[i] = "@" + user.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func privacy(private bool) string {
+	if private {
+		return "private"
+	}
+	return "public"
+}
+
+// nonEmpty drops empty values, which need no request on a new channel.
+func nonEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,278 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/oppai/slakctl/pkg/slack"
+)
+
+// fakeSlack は #dev (アーカイブ済み), #oncall, #secret (private) のある
+// ワークスペースを返し、書き込み系のリクエストを記録する。UHELPER は他の
+// ワークスペースのアプリで、users.list には含まれない
+type fakeSlack struct {
+	calls       []string
+	lookups     int
+	failLookups bool
+}
+
+func (f *fakeSlack) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response := map[string]interface{}{"ok": true}
+
+	switch r.URL.Path {
+	case "/auth.test":
+		response["user_id"] = "USELF"
+	case "/conversations.list":
+		response["channels"] = []map[string]interface{}{
+			{"id": "CDEV", "name": "dev", "is_archived": true, "topic": map[string]interface{}{"value": "old"}},
+			{"id": "CONCALL", "name": "oncall", "topic": map[string]interface{}{"value": "On call: alice"}},
+			{"id": "CSECRET", "name": "secret", "is_private": true},
+		}
+	case "/conversations.members":
+		response["members"] = []string{"USELF", "UALICE", "UCAROL", "UDEPLOYBOT", "UHELPER"}
+	case "/users.list":
+		response["members"] = []map[string]interface{}{
+			{"id": "USELF", "name": "me"},
+			{"id": "UALICE", "name": "alice"},
+			{"id": "UBOB", "name": "bob"},
+			{"id": "UCAROL", "name": "carol"},
+			{"id": "UDEPLOYBOT", "name": "deploybot", "is_bot": true},
+		}
+	case "/users.info":
+		f.lookups++
+		if f.failLookups {
+			response = map[string]interface{}{"ok": false, "error": "user_not_found"}
+			break
+		}
+		id := r.URL.Query().Get("user")
+		response["user"] = map[string]interface{}{
+			"id":          id,
+			"name":        strings.ToLower(strings.TrimPrefix(id, "U")),
+			"is_app_user": id == "UHELPER",
+		}
+	case "/conversations.create":
+		response["channel"] = map[string]interface{}{"id": "CNEW", "name": "new"}
+		fallthrough
+	default:
+		body, _ := io.ReadAll(r.Body)
+		f.calls = append(f.calls, strings.TrimPrefix(r.URL.Path, "/")+" "+string(body))
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+func newTestClient(t *testing.T) (*slack.Client, *fakeSlack) {
+	fake := &fakeSlack{}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return slack.NewClient("test-token", slack.WithBaseURL(server.URL), slack.WithRateLimiter(nil)), fake
+}
+
+func mustParse(t *testing.T, data string) *Config {
+	t.Helper()
+	config, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("failed to parse configuration: %v", err)
+	}
+	return config
+}
+
+func TestParse(t *testing.T) {
+	t.Run("should distinguish omitted and empty fields", func(t *testing.T) {
+		config := mustParse(t, `
+channels:
+  - name: "#oncall"
+    topic: ""
+    members: []
+  - name: dev
+`)
+		oncall, dev := config.Channels[0], config.Channels[1]
+		if oncall.Name != "oncall" {
+			t.Errorf("expected # to be trimmed, got: %s", oncall.Name)
+		}
+		if oncall.Topic == nil || *oncall.Topic != "" || oncall.Members == nil {
+			t.Errorf("expected empty topic and members to be managed: %+v", oncall)
+		}
+		if dev.Topic != nil || dev.Members != nil {
+			t.Errorf("expected omitted fields to be unmanaged: %+v", dev)
+		}
+	})
+
+	t.Run("should reject invalid configurations", func(t *testing.T) {
+		for _, data := range []string{
+			"channels:\n  - name: dev\n    topik: typo\n",
+			"channels:\n  - name: dev\n  - name: dev\n",
+			"channels:\n  - name: Dev\n",
+			"channels:\n  - topic: no name\n",
+		} {
+			if _, err := Parse([]byte(data)); err == nil {
+				t.Errorf("expected error for %q", data)
+			}
+		}
+	})
+}
+
+func TestBuild(t *testing.T) {
+	client, fake := newTestClient(t)
+
+	config := mustParse(t, `
+channels:
+  - name: new
+    private: true
+    topic: Fresh
+    purpose: ""
+    members: [bob, me]
+  - name: dev
+    topic: new
+  - name: oncall
+    topic: "On call: alice"
+    members: [alice, bob]
+  - name: secret
+    private: true
+`)
+
+	plan, err := Build(context.Background(), client, config)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(plan.Changes) != 3 || plan.Unchanged != 1 {
+		t.Fatalf("expected 3 changes and 1 unchanged, got: %+v", plan)
+	}
+
+	t.Run("should create missing channels", func(t *testing.T) {
+		create := plan.Changes[0]
+		if create.Action != Create || !create.Private || *create.Topic != "Fresh" || create.Purpose != nil {
+			t.Errorf("unexpected change: %+v", create)
+		}
+		if len(create.Invite) != 1 || create.Invite[0].Name != "bob" {
+			t.Errorf("expected only bob to be invited, got: %+v", create.Invite)
+		}
+	})
+
+	t.Run("should unarchive and update channels", func(t *testing.T) {
+		dev := plan.Changes[1]
+		if dev.Action != Update || dev.ID != "CDEV" || !dev.Unarchive || dev.OldTopic != "old" || *dev.Topic != "new" {
+			t.Errorf("unexpected change: %+v", dev)
+		}
+	})
+
+	t.Run("should diff members", func(t *testing.T) {
+		oncall := plan.Changes[2]
+		if oncall.Topic != nil {
+			t.Errorf("expected unchanged topic to be left out, got: %q", *oncall.Topic)
+		}
+		if len(oncall.Invite) != 1 || oncall.Invite[0].ID != "UBOB" {
+			t.Errorf("expected bob to be invited, got: %+v", oncall.Invite)
+		}
+		if len(oncall.Kick) != 1 || oncall.Kick[0].ID != "UCAROL" {
+			t.Errorf("expected carol to be removed, got: %+v", oncall.Kick)
+		}
+	})
+
+	t.Run("should not remove bots and app users", func(t *testing.T) {
+		for _, user := range plan.Changes[2].Kick {
+			if user.ID == "UDEPLOYBOT" || user.ID == "UHELPER" {
+				t.Errorf("expected %s to be kept, got: %+v", user.Name, plan.Changes[2].Kick)
+			}
+		}
+	})
+
+	t.Run("should only look up members missing from the user list", func(t *testing.T) {
+		if fake.lookups != 1 {
+			t.Errorf("expected only UHELPER to be looked up, got %d lookups", fake.lookups)
+		}
+	})
+
+	t.Run("should fail when a member cannot be looked up", func(t *testing.T) {
+		fake.failLookups = true
+		defer func() { fake.failLookups = false }()
+
+		plan, err := Build(context.Background(), client, mustParse(t, "channels:\n  - name: oncall\n    members: [alice]\n"))
+		if err == nil || !strings.Contains(err.Error(), "UHELPER") {
+			t.Errorf("expected lookup error, got: %v (%+v)", err, plan)
+		}
+	})
+
+	t.Run("should refuse to convert channels", func(t *testing.T) {
+		_, err := Build(context.Background(), client, mustParse(t, "channels:\n  - name: secret\n"))
+		if err == nil || !strings.Contains(err.Error(), "#secret is private but configured as public") {
+			t.Errorf("expected conversion error, got: %v", err)
+		}
+	})
+}
+
+func TestPlanWrite(t *testing.T) {
+	topic := "new"
+	plan := &Plan{
+		Changes: []*Change{
+			{Action: Create, Name: "new", Private: true, Topic: &topic, Invite: []slack.User{{ID: "UBOB", Name: "bob"}}},
+			{Action: Update, Name: "dev", ID: "CDEV", OldTopic: "old", Topic: &topic, Unarchive: true, Kick: []slack.User{{ID: "UCAROL", Name: "carol"}}},
+		},
+		Unchanged: 2,
+	}
+
+	var buf bytes.Buffer
+	plan.Write(&buf)
+	output := buf.String()
+
+	for _, want := range []string{
+		`  + channel "new" (private)`,
+		`      + topic    = "new"`,
+		`      + member     @bob`,
+		`  ~ channel "dev" (CDEV)`,
+		`      ~ archived = true -> false`,
+		`      ~ topic    = "old" -> "new"`,
+		`      - member     @carol`,
+		"Plan: 1 to create, 1 to change, 2 unchanged.",
+	} {
+		if !strings.Contains(output, want+"\n") {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+
+	buf.Reset()
+	(&Plan{Unchanged: 2}).Write(&buf)
+	if !strings.HasPrefix(buf.String(), "No changes.") {
+		t.Errorf("unexpected output for empty plan: %s", buf.String())
+	}
+}
+
+func TestApply(t *testing.T) {
+	client, fake := newTestClient(t)
+
+	topic := "new"
+	plan := &Plan{Changes: []*Change{
+		{Action: Create, Name: "new", Topic: &topic, Invite: []slack.User{{ID: "UBOB", Name: "bob"}}, Archive: true},
+		{Action: Update, Name: "dev", ID: "CDEV", Unarchive: true, Kick: []slack.User{{ID: "UCAROL", Name: "carol"}}},
+	}}
+
+	var steps []string
+	err := plan.Apply(context.Background(), client, func(channel, step string) {
+		steps = append(steps, channel+": "+step)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	var methods []string
+	for _, call := range fake.calls {
+		methods = append(methods, strings.Fields(call)[0])
+	}
+	want := "conversations.create conversations.setTopic conversations.invite conversations.archive conversations.unarchive conversations.kick"
+	if strings.Join(methods, " ") != want {
+		t.Errorf("unexpected calls:\n got: %v\nwant: %s", methods, want)
+	}
+	if !strings.Contains(fake.calls[1], `"channel":"CNEW"`) {
+		t.Errorf("expected new channel ID to be used, got: %s", fake.calls[1])
+	}
+	if steps[0] != "new: created (CNEW)" || steps[len(steps)-1] != "dev: removed @carol" {
+		t.Errorf("unexpected steps: %v", steps)
+	}
+}
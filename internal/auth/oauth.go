@@ -35,7 +35,7 @@ func NewSlackOAuthClient(clientID, clientSecret string) *SlackOAuthClient {
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
 		RedirectURL:  RedirectURI,
-		Scopes:       []string{"channels:history", "channels:read", "channels:write", "chat:write", "search:read"},
+		Scopes:       []string{"channels:history", "channels:read", "channels:write", "chat:write", "emoji:read", "files:read", "groups:history", "groups:read", "groups:write", "im:history", "im:read", "im:write", "mpim:history", "mpim:read", "search:read", "users:read"},
 		Endpoint: oauth2.Endpoint{
 			AuthURL:  SlackAuthURL,
 			TokenURL: SlackTokenURL,
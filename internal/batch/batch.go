@@ -0,0 +1,183 @@
+// Package batch runs an operation over many channels with a bounded
+// number of workers and records the outcome of each one in a CSV results
+// file. Results files can be read back as input, which retries only the
+// items that did not succeed.
+package batch
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Status is the outcome of one item.
+type Status string
+
+const (
+	StatusOK      Status = "ok"
+	StatusSkipped Status = "skipped"
+	StatusFailed  Status = "failed"
+	// StatusNotRun marks items that were not started because the run was
+	// cancelled.
+	StatusNotRun Status = "not run"
+)
+
+// Result is the outcome of running the operation on one item.
+type Result struct {
+	// Input is the item as read from the input, e.g. "#old-project".
+	Input string
+	// ID and Name identify the channel once it has been resolved.
+	ID   string
+	Name string
+
+	Status Status
+	// Message describes what was done, why the item was skipped, or the
+	// error.
+	Message string
+}
+
+// resultsHeader is the header of results files. The first column is what
+// ReadItems takes as input on a retry.
+var resultsHeader = []string{"channel", "id", "name", "status", "message"}
+
+// ReadItems reads channel references, one per line or CSV row, from r.
+// Only the first column is used and blank lines are ignored. If the
+// first row is a header whose first column is "channel", "id" or "name",
+// it is skipped; if the header also has a "status" column, as results
+// files do, rows that are "ok" or "skipped" are left out so that only
+// failures are retried.
+func ReadItems(r io.Reader) ([]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var items []string
+	statusColumn := -1
+
+	for line := 0; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input: %w", err)
+		}
+
+		if line == 0 && isHeader(record) {
+			for i, column := range record {
+				if strings.EqualFold(strings.TrimSpace(column), "status") {
+					statusColumn = i
+				}
+			}
+			continue
+		}
+
+		item := strings.TrimSpace(record[0])
+		if item == "" {
+			continue
+		}
+		if statusColumn >= 0 && statusColumn < len(record) {
+			switch Status(strings.TrimSpace(record[statusColumn])) {
+			case StatusOK, StatusSkipped:
+				continue
+			}
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func isHeader(record []string) bool {
+	switch strings.ToLower(strings.TrimSpace(record[0])) {
+	case "channel", "id", "name":
+		return true
+	}
+	return false
+}
+
+// Run calls fn for every item with at most workers calls in flight and
+// returns the results in input order. report, if not nil, is called with
+// each result as it completes, one at a time. Items not started before
+// ctx is cancelled are reported as not run so that they are retried.
+func Run(ctx context.Context, items []string, workers int, fn func(ctx context.Context, item string) Result, report func(Result)) []Result {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]Result, len(items))
+	indexes := make(chan int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for range min(workers, len(items)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				var result Result
+				if err := ctx.Err(); err != nil {
+					result = Result{Status: StatusNotRun, Message: err.Error()}
+				} else {
+					result = fn(ctx, items[i])
+				}
+				result.Input = items[i]
+				results[i] = result
+
+				if report != nil {
+					mu.Lock()
+					report(result)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// Count returns the number of results with status.
+func Count(results []Result, status Status) int {
+	n := 0
+	for _, result := range results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// WriteResults writes results to path as CSV.
+func WriteResults(path string, results []Result) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create results file: %w", err)
+	}
+
+	writer := csv.NewWriter(file)
+	writer.Write(resultsHeader)
+	for _, result := range results {
+		writer.Write([]string{result.Input, result.ID, result.Name, string(result.Status), result.Message})
+	}
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write results file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write results file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package batch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadItems(t *testing.T) {
+	t.Run("should read one channel per line", func(t *testing.T) {
+		items, err := ReadItems(strings.NewReader("C111\n\n#old-project\n  C222  \n"))
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !reflect.DeepEqual(items, []string{"C111", "#old-project", "C222"}) {
+			t.Errorf("unexpected items: %v", items)
+		}
+	})
+
+	t.Run("should use the first CSV column and skip headers", func(t *testing.T) {
+		items, err := ReadItems(strings.NewReader("id,name,last_message\nC111,old,2023-01-01\nC222,older,2022-01-01\n"))
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !reflect.DeepEqual(items, []string{"C111", "C222"}) {
+			t.Errorf("unexpected items: %v", items)
+		}
+	})
+
+	t.Run("should only retry failures from results files", func(t *testing.T) {
+		input := "channel,id,name,status,message\nC111,C111,a,ok,archived\nC222,C222,b,failed,rate limited\nC333,C333,c,skipped,already archived\n#gone,,,failed,channel not found: #gone\n"
+		items, err := ReadItems(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !reflect.DeepEqual(items, []string{"C222", "#gone"}) {
+			t.Errorf("unexpected items: %v", items)
+		}
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("should return results in input order with bounded concurrency", func(t *testing.T) {
+		items := []string{"a", "b", "c", "d", "e", "f"}
+		var running, peak atomic.Int32
+
+		var reported []string
+		results := Run(context.Background(), items, 2, func(ctx context.Context, item string) Result {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+
+			if item == "c" {
+				return Result{Status: StatusFailed, Message: "boom"}
+			}
+			return Result{ID: strings.ToUpper(item), Status: StatusOK}
+		}, func(result Result) {
+			reported = append(reported, result.Input)
+		})
+
+		if peak.Load() > 2 {
+			t.Errorf("expected at most 2 concurrent calls, got: %d", peak.Load())
+		}
+		if len(reported) != len(items) {
+			t.Errorf("expected every item to be reported, got: %v", reported)
+		}
+		for i, result := range results {
+			if result.Input != items[i] {
+				t.Errorf("expected result %d to be %s, got: %s", i, items[i], result.Input)
+			}
+		}
+		if Count(results, StatusOK) != 5 || Count(results, StatusFailed) != 1 {
+			t.Errorf("unexpected results: %+v", results)
+		}
+	})
+
+	t.Run("should not run items left when cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		results := Run(ctx, []string{"a", "b", "c"}, 1, func(ctx context.Context, item string) Result {
+			cancel()
+			return Result{Status: StatusOK}
+		}, nil)
+
+		if results[0].Status != StatusOK || results[1].Status != StatusNotRun || results[2].Status != StatusNotRun {
+			t.Errorf("unexpected results: %+v", results)
+		}
+	})
+}
+
+func TestWriteResults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.csv")
+	results := []Result{
+		{Input: "#a", ID: "C1", Name: "a", Status: StatusOK, Message: "archived"},
+		{Input: "#b", Status: StatusFailed, Message: "channel not found: #b, did you mean #c?"},
+	}
+
+	if err := WriteResults(path, results); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open results: %v", err)
+	}
+	defer file.Close()
+
+	items, err := ReadItems(file)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !reflect.DeepEqual(items, []string{"#b"}) {
+		t.Errorf("expected results file to retry #b only, got: %v", items)
+	}
+}
@@ -0,0 +1,229 @@
+// Package cache stores Slack directory data (channel, user and emoji
+// lists) on disk between runs. Entries live under
+// $XDG_CACHE_HOME/slakctl/<workspace>/ and expire after a per-kind TTL.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultTTLs is how long entries stay fresh, by key prefix. Keys without a
+// matching prefix use DefaultTTL.
+var DefaultTTLs = map[string]time.Duration{
+	"channels": time.Hour,
+	"users":    24 * time.Hour,
+	"emoji":    24 * time.Hour,
+}
+
+// DefaultTTL applies to keys not listed in DefaultTTLs.
+const DefaultTTL = time.Hour
+
+// Cache is the cache directory of one workspace. It implements
+// slack.Cache.
+type Cache struct {
+	dir string
+	// Refresh ignores existing entries so that everything is fetched again
+	// and rewritten.
+	Refresh bool
+
+	now func() time.Time
+}
+
+// Entry describes a cached item.
+type Entry struct {
+	Key       string
+	Path      string
+	Size      int64
+	UpdatedAt time.Time
+	Expired   bool
+}
+
+type envelope struct {
+	UpdatedAt time.Time       `json:"updated_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Dir returns the root cache directory shared by all workspaces.
+func Dir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, "slakctl"), nil
+}
+
+// Open returns the cache of workspace. The directory is created on the
+// first write.
+func Open(workspace string) (*Cache, error) {
+	root, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{dir: filepath.Join(root, workspace), now: time.Now}, nil
+}
+
+// Get decodes the entry for key into v. It reports false if there is no
+// entry, it has expired, or Refresh is set.
+func (c *Cache) Get(key string, v interface{}) (bool, error) {
+	if c.Refresh {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	var entry envelope
+	if err := json.Unmarshal(data, &entry); err != nil {
+		// 壊れたエントリはミス扱いにして取り直す
+		return false, nil
+	}
+	if c.now().Sub(entry.UpdatedAt) > ttl(key) {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(entry.Data, v); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Set stores v as the entry for key.
+func (c *Cache) Set(key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	content, err := json.Marshal(envelope{UpdatedAt: c.now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// 書き込み途中のファイルを読まないよう一時ファイルから置き換える
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+
+	return nil
+}
+
+// Invalidate removes every entry whose key starts with prefix.
+func (c *Cache) Invalidate(prefix string) error {
+	matches, err := filepath.Glob(filepath.Join(c.dir, prefix+"*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to invalidate cache: %w", err)
+	}
+	for _, path := range matches {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to invalidate cache: %w", err)
+		}
+	}
+	return nil
+}
+
+// Entries lists the cached items of the workspace, sorted by key.
+func (c *Cache) Entries() ([]Entry, error) {
+	files, err := os.ReadDir(c.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	var entries []Entry
+	for _, file := range files {
+		key, ok := strings.CutSuffix(file.Name(), ".json")
+		if !ok || file.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(c.dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cache: %w", err)
+		}
+
+		var entry envelope
+		json.Unmarshal(data, &entry)
+
+		entries = append(entries, Entry{
+			Key:       key,
+			Path:      path,
+			Size:      int64(len(data)),
+			UpdatedAt: entry.UpdatedAt,
+			Expired:   c.now().Sub(entry.UpdatedAt) > ttl(key),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries, nil
+}
+
+// Clear removes every entry of the workspace.
+func (c *Cache) Clear() error {
+	if err := os.RemoveAll(c.dir); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
+
+// ClearAll removes the cache of every workspace.
+func ClearAll() error {
+	root, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(root); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
+
+// Dir returns the directory holding the workspace's entries.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+func ttl(key string) time.Duration {
+	for prefix, ttl := range DefaultTTLs {
+		if strings.HasPrefix(key, prefix) {
+			return ttl
+		}
+	}
+	return DefaultTTL
+}
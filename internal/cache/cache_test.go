@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	c, err := Open("T123")
+	if err != nil {
+		t.Fatalf("failed to open cache: %v", err)
+	}
+	c.now = func() time.Time { return now }
+
+	var names []string
+	if ok, err := c.Get("channels_public_channel", &names); ok || err != nil {
+		t.Fatalf("expected miss on empty cache, got: %v, %v", ok, err)
+	}
+
+	if err := c.Set("channels_public_channel", []string{"general", "random"}); err != nil {
+		t.Fatalf("failed to set: %v", err)
+	}
+
+	t.Run("should return fresh entries", func(t *testing.T) {
+		var got []string
+		ok, err := c.Get("channels_public_channel", &got)
+		if !ok || err != nil || len(got) != 2 || got[0] != "general" {
+			t.Errorf("expected cached names, got: %v (%v, %v)", got, ok, err)
+		}
+	})
+
+	t.Run("should ignore entries when refreshing", func(t *testing.T) {
+		c.Refresh = true
+		defer func() { c.Refresh = false }()
+
+		var got []string
+		if ok, _ := c.Get("channels_public_channel", &got); ok {
+			t.Error("expected miss with Refresh set")
+		}
+	})
+
+	t.Run("should expire entries after their TTL", func(t *testing.T) {
+		now = now.Add(2 * time.Hour)
+		defer func() { now = now.Add(-2 * time.Hour) }()
+
+		var got []string
+		if ok, _ := c.Get("channels_public_channel", &got); ok {
+			t.Error("expected channels to expire after an hour")
+		}
+
+		entries, err := c.Entries()
+		if err != nil || len(entries) != 1 || !entries[0].Expired {
+			t.Errorf("expected one expired entry, got: %+v (%v)", entries, err)
+		}
+	})
+
+	t.Run("should keep workspaces apart", func(t *testing.T) {
+		other, err := Open("T456")
+		if err != nil {
+			t.Fatalf("failed to open cache: %v", err)
+		}
+
+		var got []string
+		if ok, _ := other.Get("channels_public_channel", &got); ok {
+			t.Error("expected miss in another workspace")
+		}
+	})
+
+	t.Run("should clear the workspace", func(t *testing.T) {
+		if err := c.Clear(); err != nil {
+			t.Fatalf("failed to clear: %v", err)
+		}
+		if _, err := os.Stat(c.Dir()); !os.IsNotExist(err) {
+			t.Errorf("expected cache directory to be removed, got: %v", err)
+		}
+	})
+}
+
+func TestDir(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", tempDir)
+
+	dir, err := Dir()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if dir != filepath.Join(tempDir, "slakctl") {
+		t.Errorf("expected cache under XDG_CACHE_HOME, got: %s", dir)
+	}
+}
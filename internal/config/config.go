@@ -9,6 +9,8 @@ import (
 
 type Config struct {
 	Token        string `json:"token"`
+	TeamID       string `json:"team_id,omitempty"`
+	AppToken     string `json:"app_token,omitempty"`
 	ClientID     string `json:"client_id"`
 	ClientSecret string `json:"client_secret"`
 }
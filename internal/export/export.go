@@ -0,0 +1,488 @@
+// Package export archives Slack channels to disk as Slack-export-compatible
+// JSON, optionally rendered as Markdown transcripts or static HTML pages.
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oppai/slakctl/pkg/slack"
+)
+
+// Format is the rendered output written next to the JSON archive.
+type Format string
+
+const (
+	FormatJSON     Format = "json"
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+)
+
+// ParseFormat parses a --format value. "md" is accepted for markdown.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case FormatJSON, FormatMarkdown, FormatHTML:
+		return Format(s), nil
+	case "md":
+		return FormatMarkdown, nil
+	}
+	return "", fmt.Errorf("invalid export format %q: use json, markdown, or html", s)
+}
+
+// Options configures an Exporter.
+type Options struct {
+	// Dir is the directory the archive is written to.
+	Dir string
+	// Format selects the rendered output. The JSON archive is always
+	// written because it is what resuming works from.
+	Format Format
+	// DownloadFiles saves attached files next to the messages.
+	DownloadFiles bool
+	// Progress is called after every batch of messages is written.
+	Progress func(channel string, messages int)
+	// Warn reports problems that do not stop the export.
+	Warn func(format string, args ...interface{})
+}
+
+// Record is a message as stored in the archive. It follows the layout of
+// Slack's own workspace export, so fields that only slakctl adds to
+// messages, such as the channel and permalink, are left out.
+type Record struct {
+	Type        string         `json:"type"`
+	Subtype     string         `json:"subtype,omitempty"`
+	Text        string         `json:"text"`
+	User        string         `json:"user,omitempty"`
+	Username    string         `json:"username,omitempty"`
+	BotID       string         `json:"bot_id,omitempty"`
+	TS          string         `json:"ts"`
+	ThreadTS    string         `json:"thread_ts,omitempty"`
+	ReplyCount  int            `json:"reply_count,omitempty"`
+	Files       []slack.File   `json:"files,omitempty"`
+	UserProfile *RecordProfile `json:"user_profile,omitempty"`
+}
+
+func newRecord(msg slack.Message) Record {
+	return Record{
+		Type:       msg.Type,
+		Subtype:    msg.Subtype,
+		Text:       msg.Text,
+		User:       msg.User,
+		Username:   msg.Username,
+		BotID:      msg.BotID,
+		TS:         msg.TS,
+		ThreadTS:   msg.ThreadTS,
+		ReplyCount: msg.ReplyCount,
+		Files:      msg.Files,
+	}
+}
+
+// RecordProfile is the author profile Slack exports store with each
+// message.
+type RecordProfile struct {
+	Name        string `json:"name"`
+	RealName    string `json:"real_name"`
+	DisplayName string `json:"display_name"`
+}
+
+// messages are written in batches so that progress can be saved regularly
+const batchSize = 200
+
+// Exporter writes channel archives to a directory.
+type Exporter struct {
+	client  *slack.Client
+	options Options
+	users   map[string]slack.User
+}
+
+// New returns an Exporter that reads channels through client.
+func New(client *slack.Client, options Options) *Exporter {
+	if options.Format == "" {
+		options.Format = FormatJSON
+	}
+	if options.Warn == nil {
+		options.Warn = func(string, ...interface{}) {}
+	}
+	return &Exporter{
+		client:  client,
+		options: options,
+		users:   make(map[string]slack.User),
+	}
+}
+
+// Export archives channels. Running it again on the same directory resumes
+// an interrupted export and then picks up messages posted since. Replies
+// posted since to threads that were already archived are not fetched.
+func (e *Exporter) Export(ctx context.Context, channels []slack.Channel) error {
+	dir := e.options.Dir
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	st, err := loadState(dir)
+	if err != nil {
+		return err
+	}
+
+	if err := e.loadUsers(ctx); err != nil {
+		return err
+	}
+
+	for _, channel := range channels {
+		if err := e.exportChannel(ctx, channel, st); err != nil {
+			return err
+		}
+	}
+
+	if err := e.writeIndex(channels); err != nil {
+		return err
+	}
+
+	for _, channel := range channels {
+		if err := e.render(channel); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Exporter) loadUsers(ctx context.Context) error {
+	users, err := e.client.ListUsersContext(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		// ユーザー名が解決できなくてもエクスポート自体は続ける
+		e.options.Warn("could not list users, user IDs will not be resolved: %v", err)
+		return nil
+	}
+	for _, user := range users {
+		e.users[user.ID] = user
+	}
+	return nil
+}
+
+func (e *Exporter) user(ctx context.Context, id string) (slack.User, bool) {
+	if user, ok := e.users[id]; ok {
+		return user, true
+	}
+
+	user, err := e.client.GetUserInfo(ctx, id)
+	if err != nil {
+		// 同じユーザーを何度も問い合わせないよう ID だけ記録する
+		e.users[id] = slack.User{ID: id, Name: id}
+		return slack.User{}, false
+	}
+	e.users[id] = *user
+	return *user, true
+}
+
+func (e *Exporter) exportChannel(ctx context.Context, channel slack.Channel, st *state) error {
+	cs := st.channel(channel.ID, channel.Name)
+	channelDir := filepath.Join(e.options.Dir, channelDirName(channel))
+	if err := os.MkdirAll(channelDir, 0755); err != nil {
+		return fmt.Errorf("failed to create channel directory: %w", err)
+	}
+
+	options := slack.HistoryOptions{
+		Oldest: tsTime(cs.ExportedUntil),
+		Latest: tsTime(cs.WindowBottom),
+	}
+
+	written := 0
+	var batch []slack.Message
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := e.writeBatch(ctx, channel, channelDir, batch); err != nil {
+			return err
+		}
+		written += len(batch)
+		cs.WindowBottom = batch[len(batch)-1].TS
+		batch = nil
+		if e.options.Progress != nil {
+			e.options.Progress(channel.Name, written)
+		}
+		return st.save(e.options.Dir)
+	}
+
+	for msg, err := range e.client.History(ctx, channel.ID, options) {
+		if err != nil {
+			if flushErr := flush(); flushErr != nil {
+				return flushErr
+			}
+			return fmt.Errorf("failed to export #%s: %w", channel.Name, err)
+		}
+
+		if cs.WindowTop == "" {
+			cs.WindowTop = msg.TS
+		}
+		batch = append(batch, msg)
+
+		if len(batch) >= batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	// 今回の範囲を最後まで取得できた
+	if cs.WindowTop != "" {
+		cs.ExportedUntil = cs.WindowTop
+	}
+	cs.WindowTop = ""
+	cs.WindowBottom = ""
+	return st.save(e.options.Dir)
+}
+
+// writeBatch fetches thread replies and files for messages and merges
+// everything into the per-day JSON files.
+func (e *Exporter) writeBatch(ctx context.Context, channel slack.Channel, channelDir string, messages []slack.Message) error {
+	all := make([]slack.Message, 0, len(messages))
+	for _, msg := range messages {
+		all = append(all, msg)
+		if !msg.IsThreadParent() {
+			continue
+		}
+
+		replies, err := e.client.RepliesContext(ctx, channel.ID, msg.TS)
+		if err != nil {
+			return fmt.Errorf("failed to export replies in #%s: %w", channel.Name, err)
+		}
+		all = append(all, replies...)
+	}
+
+	days := make(map[string][]Record)
+	for _, msg := range all {
+		if e.options.DownloadFiles {
+			e.downloadFiles(ctx, channelDir, msg.Files)
+		}
+
+		record := newRecord(msg)
+		if msg.User != "" {
+			if user, ok := e.user(ctx, msg.User); ok {
+				record.UserProfile = &RecordProfile{
+					Name:        user.Name,
+					RealName:    user.RealName,
+					DisplayName: user.Profile.DisplayName,
+				}
+			}
+		}
+
+		day := tsTime(msg.TS).UTC().Format(time.DateOnly)
+		days[day] = append(days[day], record)
+	}
+
+	for day, records := range days {
+		if err := mergeDayFile(filepath.Join(channelDir, day+".json"), records); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+func (e *Exporter) downloadFiles(ctx context.Context, channelDir string, files []slack.File) {
+	for _, file := range files {
+		fileURL := file.URLPrivateDownload
+		if fileURL == "" {
+			fileURL = file.URLPrivate
+		}
+		if fileURL == "" {
+			continue
+		}
+
+		path := filepath.Join(channelDir, localFilePath(file))
+		if _, err := os.Stat(path); err == nil {
+			continue // 再開時はダウンロード済みのファイルを飛ばす
+		}
+
+		if err := e.saveFile(ctx, fileURL, path); err != nil {
+			e.options.Warn("could not download %s: %v", file.Name, err)
+		}
+	}
+}
+
+func (e *Exporter) saveFile(ctx context.Context, fileURL, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := e.client.DownloadFile(ctx, fileURL, tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// mergeDayFile adds records to the JSON file of a day, replacing records
+// with the same timestamp, and keeps the file sorted oldest first.
+func mergeDayFile(path string, records []Record) error {
+	existing, err := readDayFile(path)
+	if err != nil {
+		return err
+	}
+
+	byTS := make(map[string]Record, len(existing)+len(records))
+	for _, record := range existing {
+		byTS[record.TS] = record
+	}
+	for _, record := range records {
+		byTS[record.TS] = record
+	}
+
+	merged := make([]Record, 0, len(byTS))
+	for _, record := range byTS {
+		merged = append(merged, record)
+	}
+	sortRecords(merged)
+
+	data, err := json.MarshalIndent(merged, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", path, err)
+	}
+	return writeFileAtomic(path, data)
+}
+
+func readDayFile(path string) ([]Record, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var records []Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return records, nil
+}
+
+// readChannel returns every archived record of a channel, oldest first.
+func readChannel(channelDir string) ([]Record, error) {
+	paths, err := filepath.Glob(filepath.Join(channelDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var all []Record
+	for _, path := range paths {
+		records, err := readDayFile(path)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, records...)
+	}
+	sortRecords(all)
+	return all, nil
+}
+
+// writeIndex writes channels.json and users.json. Channels exported by
+// earlier runs into the same directory are kept in channels.json, updated
+// by ID with the channels of this run.
+func (e *Exporter) writeIndex(channels []slack.Channel) error {
+	path := filepath.Join(e.options.Dir, "channels.json")
+	var index []slack.Channel
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read channels.json: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &index); err != nil {
+			return fmt.Errorf("failed to parse channels.json: %w", err)
+		}
+	}
+
+	positions := make(map[string]int, len(index))
+	for i, channel := range index {
+		positions[channel.ID] = i
+	}
+	for _, channel := range channels {
+		if i, ok := positions[channel.ID]; ok {
+			index[i] = channel
+			continue
+		}
+		positions[channel.ID] = len(index)
+		index = append(index, channel)
+	}
+
+	channelsData, err := json.MarshalIndent(index, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal channels: %w", err)
+	}
+	if err := writeFileAtomic(path, channelsData); err != nil {
+		return err
+	}
+
+	users := make([]slack.User, 0, len(e.users))
+	for _, user := range e.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	usersData, err := json.MarshalIndent(users, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal users: %w", err)
+	}
+	return writeFileAtomic(filepath.Join(e.options.Dir, "users.json"), usersData)
+}
+
+func sortRecords(records []Record) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return tsTime(records[i].TS).Before(tsTime(records[j].TS))
+	})
+}
+
+func tsTime(ts string) time.Time {
+	if ts == "" {
+		return time.Time{}
+	}
+	t, err := slack.ParseTimestamp(ts)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func channelDirName(channel slack.Channel) string {
+	if channel.Name == "" {
+		return channel.ID
+	}
+	return unsafeFileChars.ReplaceAllString(channel.Name, "_")
+}
+
+// localFilePath returns where a downloaded file is stored, relative to the
+// channel directory.
+func localFilePath(file slack.File) string {
+	name := unsafeFileChars.ReplaceAllString(file.Name, "_")
+	name = strings.Trim(name, ".")
+	if name == "" {
+		name = "file"
+	}
+	return filepath.Join("files", file.ID+"-"+name)
+}
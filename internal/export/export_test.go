@@ -0,0 +1,328 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oppai/slakctl/internal/timefmt"
+	"github.com/oppai/slakctl/pkg/slack"
+)
+
+// fakeSlack は conversations.history / replies / users.list とファイルダウンロードを返すスタブ
+type fakeSlack struct {
+	mu       sync.Mutex
+	messages []map[string]interface{} // 新しい順
+	queries  []string
+}
+
+func (f *fakeSlack) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		var response map[string]interface{}
+		switch r.URL.Path {
+		case "/api/conversations.history":
+			f.queries = append(f.queries, r.URL.RawQuery)
+			oldest := tsTime(r.URL.Query().Get("oldest"))
+			latest := tsTime(r.URL.Query().Get("latest"))
+
+			var messages []map[string]interface{}
+			for _, msg := range f.messages {
+				ts := tsTime(msg["ts"].(string))
+				if !oldest.IsZero() && !ts.After(oldest) {
+					continue
+				}
+				if !latest.IsZero() && !ts.Before(latest) {
+					continue
+				}
+				messages = append(messages, msg)
+			}
+			response = map[string]interface{}{"ok": true, "messages": messages}
+
+		case "/api/conversations.replies":
+			response = map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"user": "U1", "text": "parent", "ts": r.URL.Query().Get("ts")},
+					{"user": "U2", "text": "reply from <@U1>", "ts": "1700000100.000000", "thread_ts": r.URL.Query().Get("ts")},
+				},
+			}
+
+		case "/api/users.list":
+			response = map[string]interface{}{
+				"ok": true,
+				"members": []map[string]interface{}{
+					{"id": "U1", "name": "alice", "profile": map[string]string{"display_name": "Alice"}},
+					{"id": "U2", "name": "bob", "profile": map[string]string{"real_name": "Bob"}},
+				},
+			}
+
+		case "/files/report.txt":
+			w.Write([]byte("report contents"))
+			return
+
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func newTestExporter(t *testing.T, fake *fakeSlack, options Options) (*Exporter, *httptest.Server) {
+	server := httptest.NewServer(fake.handler(t))
+	client := slack.NewClient("test-token",
+		slack.WithBaseURL(server.URL+"/api"),
+		slack.WithTransport(server.Client().Transport),
+		slack.WithRateLimiter(nil),
+	)
+	return New(client, options), server
+}
+
+func TestExport(t *testing.T) {
+	dir := t.TempDir()
+	fake := &fakeSlack{}
+
+	var server *httptest.Server
+	exporter, server := newTestExporter(t, fake, Options{Dir: dir, Format: FormatMarkdown, DownloadFiles: true})
+	defer server.Close()
+
+	fake.messages = []map[string]interface{}{
+		{"user": "U2", "text": "with file", "ts": "1700086400.000000", "files": []map[string]interface{}{
+			{"id": "F1", "name": "report.txt", "url_private_download": server.URL + "/files/report.txt"},
+		}},
+		{"user": "U1", "text": "parent", "ts": "1700000000.000000", "thread_ts": "1700000000.000000", "reply_count": 1},
+	}
+
+	channel := slack.Channel{ID: "C1", Name: "general"}
+	if err := exporter.Export(context.Background(), []slack.Channel{channel}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	day1, err := readDayFile(filepath.Join(dir, "general", "2023-11-14.json"))
+	if err != nil {
+		t.Fatalf("failed to read day file: %v", err)
+	}
+	if len(day1) != 2 {
+		t.Fatalf("expected parent and reply in first day, got: %d", len(day1))
+	}
+	if day1[0].UserProfile == nil || day1[0].UserProfile.Name != "alice" {
+		t.Errorf("expected user profile for alice, got: %+v", day1[0].UserProfile)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "general", "2023-11-14.json"))
+	if err != nil {
+		t.Fatalf("failed to read day file: %v", err)
+	}
+	if strings.Contains(string(raw), `"channel"`) || strings.Contains(string(raw), `"permalink"`) {
+		t.Errorf("expected Slack export fields only, got:\n%s", raw)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "general", "2023-11-15.json")); err != nil {
+		t.Errorf("expected second day file: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "general", "files", "F1-report.txt"))
+	if err != nil || string(data) != "report contents" {
+		t.Errorf("expected downloaded file, got: %q (%v)", data, err)
+	}
+
+	markdown, err := os.ReadFile(filepath.Join(dir, "general.md"))
+	if err != nil {
+		t.Fatalf("failed to read markdown: %v", err)
+	}
+	for _, expected := range []string{"# #general", "**Alice**", "> reply from @Alice", "[report.txt](general/files/F1-report.txt)"} {
+		if !strings.Contains(string(markdown), expected) {
+			t.Errorf("expected markdown to contain %q, got:\n%s", expected, markdown)
+		}
+	}
+
+	for _, name := range []string{"channels.json", "users.json", stateFileName} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s to be written: %v", name, err)
+		}
+	}
+
+	t.Run("should only fetch new messages on the next run", func(t *testing.T) {
+		fake.messages = append([]map[string]interface{}{
+			{"user": "U1", "text": "newer", "ts": "1700086500.000000"},
+		}, fake.messages...)
+		fake.queries = nil
+
+		if err := exporter.Export(context.Background(), []slack.Channel{channel}); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if len(fake.queries) != 1 || !strings.Contains(fake.queries[0], "oldest=1700086400.000000") {
+			t.Errorf("expected history to start after the last export, got: %v", fake.queries)
+		}
+
+		day2, err := readDayFile(filepath.Join(dir, "general", "2023-11-15.json"))
+		if err != nil {
+			t.Fatalf("failed to read day file: %v", err)
+		}
+		if len(day2) != 2 {
+			t.Errorf("expected 2 messages without duplicates, got: %d", len(day2))
+		}
+	})
+}
+
+func TestExportResume(t *testing.T) {
+	dir := t.TempDir()
+
+	// 前回は 1700000000 まで書き出した後、1700086500〜1700086400 の途中で中断した
+	st := &state{Channels: map[string]*channelState{
+		"C1": {Name: "general", ExportedUntil: "1700000000.000000", WindowTop: "1700086500.000000", WindowBottom: "1700086400.000000"},
+	}}
+	if err := st.save(dir); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+
+	fake := &fakeSlack{messages: []map[string]interface{}{
+		{"user": "U1", "text": "newer", "ts": "1700086500.000000"},
+		{"user": "U1", "text": "written", "ts": "1700086400.000000"},
+		{"user": "U1", "text": "missing", "ts": "1700050000.000000"},
+		{"user": "U1", "text": "old", "ts": "1700000000.000000"},
+	}}
+
+	exporter, server := newTestExporter(t, fake, Options{Dir: dir})
+	defer server.Close()
+
+	if err := exporter.Export(context.Background(), []slack.Channel{{ID: "C1", Name: "general"}}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(fake.queries) != 1 || !strings.Contains(fake.queries[0], "latest=1700086400.000000") || !strings.Contains(fake.queries[0], "oldest=1700000000.000000") {
+		t.Errorf("expected history to resume inside the window, got: %v", fake.queries)
+	}
+
+	records, err := readChannel(filepath.Join(dir, "general"))
+	if err != nil {
+		t.Fatalf("failed to read channel: %v", err)
+	}
+	if len(records) != 1 || records[0].Text != "missing" {
+		t.Errorf("expected only the missing message, got: %+v", records)
+	}
+
+	resumed, err := loadState(dir)
+	if err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+	cs := resumed.Channels["C1"]
+	if cs.ExportedUntil != "1700086500.000000" || cs.WindowTop != "" || cs.WindowBottom != "" {
+		t.Errorf("expected completed window, got: %+v", cs)
+	}
+}
+
+func TestExportKeepsEarlierChannels(t *testing.T) {
+	dir := t.TempDir()
+	earlier, _ := json.Marshal([]slack.Channel{{ID: "C0", Name: "random"}, {ID: "C1", Name: "general-old"}})
+	os.WriteFile(filepath.Join(dir, "channels.json"), earlier, 0644)
+
+	exporter, server := newTestExporter(t, &fakeSlack{}, Options{Dir: dir})
+	defer server.Close()
+
+	if err := exporter.Export(context.Background(), []slack.Channel{{ID: "C1", Name: "general"}, {ID: "C2", Name: "dev"}}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "channels.json"))
+	if err != nil {
+		t.Fatalf("failed to read channels.json: %v", err)
+	}
+	var channels []slack.Channel
+	if err := json.Unmarshal(data, &channels); err != nil {
+		t.Fatalf("failed to parse channels.json: %v", err)
+	}
+	var names []string
+	for _, channel := range channels {
+		names = append(names, channel.ID+"="+channel.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"C0=random", "C1=general", "C2=dev"}) {
+		t.Errorf("expected earlier channels to be kept and updated, got: %v", names)
+	}
+}
+
+func TestRenderMarkdownFileLinks(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "general", "files"), 0755)
+	os.WriteFile(filepath.Join(dir, "general", "files", "F1-a_b_c.txt"), []byte("notes"), 0644)
+
+	exporter := New(nil, Options{Dir: dir, Format: FormatMarkdown})
+	days := groupByDay([]Record{
+		{User: "U1", Text: "files", TS: "1700000000.000000", Files: []slack.File{
+			{ID: "F1", Name: "a](b)\nc.txt"},
+			{ID: "F2", Name: "[draft] plan.pdf", Permalink: "https://example.slack.com/files/F2/plan (v2).pdf"},
+		}},
+	})
+
+	markdown := exporter.renderMarkdown(slack.Channel{ID: "C1", Name: "general"}, days)
+	for _, expected := range []string{
+		`📎 [a\]\(b\) c.txt](general/files/F1-a_b_c.txt)`,
+		`📎 [\[draft\] plan.pdf](https://example.slack.com/files/F2/plan%20%28v2%29.pdf)`,
+	} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("expected markdown to contain %q, got:\n%s", expected, markdown)
+		}
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	defer func(f timefmt.Formatter) { *timefmt.Default = f }(*timefmt.Default)
+	timefmt.Default.Location = time.FixedZone("JST", 9*60*60)
+	timefmt.Default.Layout = "15:04"
+
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "general", "files"), 0755)
+	os.WriteFile(filepath.Join(dir, "general", "files", "F1-notes.txt"), []byte("notes"), 0644)
+	os.WriteFile(filepath.Join(dir, "general", "files", "F3-page.html"), []byte("<html><script>alert(1)</script></html>"), 0644)
+
+	exporter := New(nil, Options{Dir: dir, Format: FormatHTML})
+	days := groupByDay([]Record{
+		{User: "U1", Text: "<script>alert(1)</script>", TS: "1700000000.000000", Files: []slack.File{
+			{ID: "F1", Name: "notes.txt"},
+			{ID: "F2", Name: "remote.pdf", Permalink: "https://example.slack.com/files/F2"},
+			{ID: "F3", Name: "page.html", Mimetype: "text/html,<script>"},
+		}},
+	})
+
+	html, err := exporter.renderHTML(slack.Channel{ID: "C1", Name: "general"}, days)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if strings.Contains(string(html), "<script>") {
+		t.Error("expected message text to be escaped")
+	}
+	for _, expected := range []string{
+		"<h1>#general</h1>",
+		"<h2>2023-11-15</h2>",
+		`<span class="time">07:13</span>`,
+		`<a href="data:text/plain;base64,bm90ZXM=" download="notes.txt">notes.txt</a>`,
+		`<a href="https://example.slack.com/files/F2">remote.pdf</a>`,
+		`<a href="data:application/octet-stream;base64,`,
+	} {
+		if !strings.Contains(string(html), expected) {
+			t.Errorf("expected HTML to contain %q, got:\n%s", expected, html)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for input, expected := range map[string]Format{"json": FormatJSON, "markdown": FormatMarkdown, "md": FormatMarkdown, "html": FormatHTML} {
+		if format, err := ParseFormat(input); err != nil || format != expected {
+			t.Errorf("ParseFormat(%s) = %s, %v", input, format, err)
+		}
+	}
+	if _, err := ParseFormat("pdf"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
@@ -0,0 +1,321 @@
+package export
+
+import (
+	"encoding/base64"
+	"fmt"
+	htmltemplate "html/template"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/oppai/slakctl/internal/timefmt"
+	"github.com/oppai/slakctl/pkg/slack"
+)
+
+// thread is a top-level message with the replies archived for it.
+type thread struct {
+	Record
+	Replies []Record
+}
+
+// dayGroup is the threads started on one day.
+type dayGroup struct {
+	Date    string
+	Threads []thread
+}
+
+func (e *Exporter) render(channel slack.Channel) error {
+	if e.options.Format == FormatJSON {
+		return nil
+	}
+
+	channelDir := filepath.Join(e.options.Dir, channelDirName(channel))
+	records, err := readChannel(channelDir)
+	if err != nil {
+		return err
+	}
+	days := groupByDay(records)
+
+	var content []byte
+	var ext string
+	switch e.options.Format {
+	case FormatMarkdown:
+		content, ext = []byte(e.renderMarkdown(channel, days)), ".md"
+	case FormatHTML:
+		html, err := e.renderHTML(channel, days)
+		if err != nil {
+			return err
+		}
+		content, ext = html, ".html"
+	}
+
+	return writeFileAtomic(filepath.Join(e.options.Dir, channelDirName(channel)+ext), content)
+}
+
+// groupByDay attaches replies to their parent and groups threads by the
+// day they were started in the --tz time zone. Replies whose parent is not
+// archived are shown as top-level messages.
+func groupByDay(records []Record) []dayGroup {
+	parents := make(map[string]int)
+	var threads []thread
+	for _, record := range records {
+		isReply := record.ThreadTS != "" && record.ThreadTS != record.TS
+		if i, ok := parents[record.ThreadTS]; isReply && ok {
+			threads[i].Replies = append(threads[i].Replies, record)
+			continue
+		}
+		parents[record.TS] = len(threads)
+		threads = append(threads, thread{Record: record})
+	}
+
+	var days []dayGroup
+	for _, t := range threads {
+		date := timefmt.Default.In(tsTime(t.TS)).Format(time.DateOnly)
+		if len(days) == 0 || days[len(days)-1].Date != date {
+			days = append(days, dayGroup{Date: date})
+		}
+		days[len(days)-1].Threads = append(days[len(days)-1].Threads, t)
+	}
+	return days
+}
+
+func (e *Exporter) displayName(record Record) string {
+	if record.UserProfile != nil {
+		for _, name := range []string{record.UserProfile.DisplayName, record.UserProfile.RealName, record.UserProfile.Name} {
+			if name != "" {
+				return name
+			}
+		}
+	}
+	if record.Username != "" {
+		return record.Username
+	}
+	if record.User != "" {
+		return record.User
+	}
+	return "unknown"
+}
+
+var mentionPattern = regexp.MustCompile(`<@([UW][A-Z0-9]+)(?:\|[^>]*)?>`)
+
+// plainText replaces user mentions with names.
+func (e *Exporter) plainText(text string) string {
+	return mentionPattern.ReplaceAllStringFunc(text, func(m string) string {
+		id := mentionPattern.FindStringSubmatch(m)[1]
+		if user, ok := e.users[id]; ok {
+			return "@" + user.DisplayName()
+		}
+		return "@" + id
+	})
+}
+
+func (e *Exporter) renderMarkdown(channel slack.Channel, days []dayGroup) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# #%s\n", channel.Name)
+
+	writeMessage := func(record Record, prefix string) {
+		fmt.Fprintf(&b, "%s**%s** _%s_\n", prefix, e.displayName(record), timefmt.Default.FormatTS(record.TS))
+		for _, line := range strings.Split(e.plainText(record.Text), "\n") {
+			fmt.Fprintf(&b, "%s%s\n", prefix, line)
+		}
+		for _, file := range record.Files {
+			fmt.Fprintf(&b, "%s📎 [%s](%s)\n", prefix, markdownEscaper.Replace(file.Name), e.fileLink(channel, file))
+		}
+	}
+
+	for _, day := range days {
+		fmt.Fprintf(&b, "\n## %s\n", day.Date)
+		for _, t := range day.Threads {
+			b.WriteString("\n")
+			writeMessage(t.Record, "")
+			for _, reply := range t.Replies {
+				b.WriteString(">\n")
+				writeMessage(reply, "> ")
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// markdownEscaper keeps file names from ending Markdown link text early.
+var markdownEscaper = strings.NewReplacer(
+	"\\", "\\\\", "[", "\\[", "]", "\\]", "(", "\\(", ")", "\\)",
+	"*", "\\*", "_", "\\_", "`", "\\`", "<", "\\<", ">", "\\>",
+	"\r", " ", "\n", " ",
+)
+
+// linkEscaper percent-encodes the characters that end a Markdown link
+// destination.
+var linkEscaper = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E", "\r", "", "\n", "")
+
+// fileLink points at the downloaded copy of file if there is one, and at
+// Slack otherwise. The result is escaped for use as a Markdown link
+// destination.
+func (e *Exporter) fileLink(channel slack.Channel, file slack.File) string {
+	local := localFilePath(file)
+	if _, err := os.Stat(filepath.Join(e.options.Dir, channelDirName(channel), local)); err == nil {
+		segments := strings.Split(filepath.ToSlash(filepath.Join(channelDirName(channel), local)), "/")
+		for i, segment := range segments {
+			segments[i] = url.PathEscape(segment)
+		}
+		return strings.Join(segments, "/")
+	}
+	return linkEscaper.Replace(file.Permalink)
+}
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("channel").Parse(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>#{{.Channel.Name}}</title>
+    <style>
+        body { font-family: -apple-system, "Segoe UI", Arial, sans-serif; margin: 40px auto; max-width: 860px; color: #1d1c1d; }
+        h2 { border-bottom: 1px solid #ddd; font-size: 1em; padding-bottom: 4px; margin-top: 32px; color: #616061; }
+        .message { margin: 12px 0; }
+        .user { font-weight: bold; }
+        .time { color: #616061; font-size: 0.85em; margin-left: 6px; }
+        .text { white-space: pre-wrap; }
+        .replies { border-left: 3px solid #ddd; margin-left: 8px; padding-left: 12px; }
+        .file { font-size: 0.9em; }
+        .file img { max-width: 100%; max-height: 480px; }
+    </style>
+</head>
+<body>
+<h1>#{{.Channel.Name}}</h1>
+{{- range .Days}}
+<h2>{{.Date}}</h2>
+{{- range .Threads}}
+{{template "message" .Message}}
+{{- if .Replies}}
+<div class="replies">
+{{- range .Replies}}
+{{template "message" .}}
+{{- end}}
+</div>
+{{- end}}
+{{- end}}
+{{- end}}
+</body>
+</html>
+{{define "message"}}<div class="message">
+    <span class="user">{{.User}}</span><span class="time">{{.Time}}</span>
+    <div class="text">{{.Text}}</div>
+    {{- range .Files}}
+    {{- if .Data}}
+    {{- if .Image}}
+    <div class="file"><img src="{{.Data}}" alt="{{.Name}}"></div>
+    {{- end}}
+    <div class="file">📎 <a href="{{.Data}}" download="{{.Name}}">{{.Name}}</a></div>
+    {{- else}}
+    <div class="file">📎 <a href="{{.Link}}">{{.Name}}</a></div>
+    {{- end}}
+    {{- end}}
+</div>{{end}}`))
+
+// htmlFile is an attachment. Downloaded files are embedded as a data URL
+// in Data so that the HTML transcript works on its own; other files link
+// to Slack.
+type htmlFile struct {
+	Name  string
+	Link  string
+	Data  htmltemplate.URL
+	Image bool
+}
+
+type htmlMessage struct {
+	User  string
+	Time  string
+	Text  string
+	Files []htmlFile
+}
+
+type htmlThread struct {
+	Message htmlMessage
+	Replies []htmlMessage
+}
+
+type htmlDay struct {
+	Date    string
+	Threads []htmlThread
+}
+
+func (e *Exporter) renderHTML(channel slack.Channel, days []dayGroup) ([]byte, error) {
+	toMessage := func(record Record) htmlMessage {
+		msg := htmlMessage{
+			User: e.displayName(record),
+			Time: timefmt.Default.FormatTS(record.TS),
+			Text: e.plainText(record.Text),
+		}
+		for _, file := range record.Files {
+			msg.Files = append(msg.Files, e.htmlFile(channel, file))
+		}
+		return msg
+	}
+
+	data := struct {
+		Channel slack.Channel
+		Days    []htmlDay
+	}{Channel: channel}
+
+	for _, day := range days {
+		hd := htmlDay{Date: day.Date}
+		for _, t := range day.Threads {
+			ht := htmlThread{Message: toMessage(t.Record)}
+			for _, reply := range t.Replies {
+				ht.Replies = append(ht.Replies, toMessage(reply))
+			}
+			hd.Threads = append(hd.Threads, ht)
+		}
+		data.Days = append(data.Days, hd)
+	}
+
+	var b strings.Builder
+	if err := htmlTemplate.Execute(&b, data); err != nil {
+		return nil, fmt.Errorf("failed to render HTML: %w", err)
+	}
+	return []byte(b.String()), nil
+}
+
+func (e *Exporter) htmlFile(channel slack.Channel, file slack.File) htmlFile {
+	f := htmlFile{Name: file.Name, Link: file.Permalink}
+
+	data, err := os.ReadFile(filepath.Join(e.options.Dir, channelDirName(channel), localFilePath(file)))
+	if err != nil {
+		// ダウンロードしていないファイルは Slack へのリンクにする
+		return f
+	}
+
+	mimetype := dataMediaType(data)
+	// data URL は許可した型と base64 だけで組み立てるので安全なものとして扱う
+	f.Data = htmltemplate.URL("data:" + mimetype + ";base64," + base64.StdEncoding.EncodeToString(data))
+	f.Image = strings.HasPrefix(mimetype, "image/")
+	return f
+}
+
+// embeddableTypes are the media types downloaded files are embedded with.
+// Anything else, including HTML and SVG that could run scripts, is
+// embedded as application/octet-stream.
+var embeddableTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"image/bmp":       true,
+	"application/pdf": true,
+	"text/plain":      true,
+}
+
+// dataMediaType returns the media type of a data URL for data. The type is
+// sniffed from the content rather than taken from Slack.
+func dataMediaType(data []byte) string {
+	mimetype, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	if embeddableTypes[mimetype] {
+		return mimetype
+	}
+	return "application/octet-stream"
+}
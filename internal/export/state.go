@@ -0,0 +1,90 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// stateFileName is the file in the export directory that records progress
+// so an interrupted export can resume.
+const stateFileName = ".slakctl-export.json"
+
+type state struct {
+	Channels map[string]*channelState `json:"channels"`
+}
+
+// channelState tracks how much of a channel has been exported. History is
+// walked from newest to oldest, so a run exports the window
+// (ExportedUntil, WindowTop] and moves WindowBottom down as pages are
+// written. Once the walk reaches ExportedUntil the window is complete and
+// ExportedUntil becomes WindowTop.
+type channelState struct {
+	Name          string `json:"name"`
+	ExportedUntil string `json:"exported_until,omitempty"`
+	WindowTop     string `json:"window_top,omitempty"`
+	WindowBottom  string `json:"window_bottom,omitempty"`
+}
+
+func loadState(dir string) (*state, error) {
+	s := &state{Channels: make(map[string]*channelState)}
+
+	data, err := os.ReadFile(filepath.Join(dir, stateFileName))
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse export state: %w", err)
+	}
+	if s.Channels == nil {
+		s.Channels = make(map[string]*channelState)
+	}
+
+	return s, nil
+}
+
+func (s *state) save(dir string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal export state: %w", err)
+	}
+	return writeFileAtomic(filepath.Join(dir, stateFileName), data)
+}
+
+func (s *state) channel(id, name string) *channelState {
+	cs, ok := s.Channels[id]
+	if !ok {
+		cs = &channelState{}
+		s.Channels[id] = cs
+	}
+	cs.Name = name
+	return cs
+}
+
+// writeFileAtomic writes data to a temporary file and renames it over path
+// so that an interrupted export never leaves a truncated file behind.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
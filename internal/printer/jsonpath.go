@@ -0,0 +1,296 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a kubectl-style JSONPath template. It supports the subset
+// that covers everyday use:
+//
+//	{.field.nested}   fields of the current value
+//	{[0]} {[*]}       array index and wildcard
+//	{$} {@}           the root and the current value
+//	{"\t"}            string literals
+//	{range ...}{end}  iterate over the results of an expression
+//
+// Text outside braces is copied as is. Multiple results of one expression
+// are separated by spaces.
+type jsonPath struct {
+	nodes []jpNode
+}
+
+type jpKind int
+
+const (
+	jpText jpKind = iota
+	jpExpr
+	jpRange
+)
+
+type jpNode struct {
+	kind jpKind
+	text string
+	// path は jpExpr と jpRange の式
+	path     jpPath
+	children []jpNode
+}
+
+type jpPath struct {
+	fromRoot bool
+	segments []jpSegment
+}
+
+type jpSegment struct {
+	field    string
+	index    int
+	wildcard bool
+	isIndex  bool
+}
+
+func parseJSONPath(template string) (*jsonPath, error) {
+	root := []jpNode{}
+	// range のネストを表すスタック
+	stack := [][]jpNode{}
+
+	rest := template
+	for rest != "" {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			root = append(root, jpNode{kind: jpText, text: rest})
+			break
+		}
+		if open > 0 {
+			root = append(root, jpNode{kind: jpText, text: rest[:open]})
+		}
+
+		end := closingBrace(rest[open:])
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed '{' in %q", template)
+		}
+		expr := strings.TrimSpace(rest[open+1 : open+end])
+		rest = rest[open+end+1:]
+
+		switch {
+		case expr == "end":
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("{end} without {range}")
+			}
+			body := root
+			root = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			root[len(root)-1].children = body
+
+		case strings.HasPrefix(expr, "range "):
+			path, err := parsePath(strings.TrimSpace(strings.TrimPrefix(expr, "range ")))
+			if err != nil {
+				return nil, err
+			}
+			root = append(root, jpNode{kind: jpRange, path: path})
+			stack = append(stack, root)
+			root = []jpNode{}
+
+		case strings.HasPrefix(expr, `"`):
+			text, err := strconv.Unquote(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string literal %s", expr)
+			}
+			root = append(root, jpNode{kind: jpText, text: text})
+
+		default:
+			path, err := parsePath(expr)
+			if err != nil {
+				return nil, err
+			}
+			root = append(root, jpNode{kind: jpExpr, path: path})
+		}
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("{range} without {end}")
+	}
+	return &jsonPath{nodes: root}, nil
+}
+
+// closingBrace returns the index of the '}' matching the '{' at s[0],
+// skipping braces inside string literals.
+func closingBrace(s string) int {
+	inString := false
+	for i := 1; i < len(s); i++ {
+		switch {
+		case inString && s[i] == '\\':
+			i++
+		case s[i] == '"':
+			inString = !inString
+		case !inString && s[i] == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+func parsePath(expr string) (jpPath, error) {
+	var path jpPath
+
+	switch {
+	case strings.HasPrefix(expr, "$"):
+		path.fromRoot = true
+		expr = expr[1:]
+	case strings.HasPrefix(expr, "@"):
+		expr = expr[1:]
+	case expr == "" || (expr[0] != '.' && expr[0] != '['):
+		return path, fmt.Errorf("invalid expression %q: expressions start with '.', '[', '$' or '@'", expr)
+	}
+
+	for expr != "" {
+		switch expr[0] {
+		case '.':
+			expr = expr[1:]
+			n := strings.IndexAny(expr, ".[")
+			if n < 0 {
+				n = len(expr)
+			}
+			field := expr[:n]
+			expr = expr[n:]
+			switch field {
+			case "":
+				// "." だけ、または ".[0]" のような書き方は現在の値を指す
+			case "*":
+				path.segments = append(path.segments, jpSegment{wildcard: true})
+			default:
+				path.segments = append(path.segments, jpSegment{field: field})
+			}
+
+		case '[':
+			n := strings.IndexByte(expr, ']')
+			if n < 0 {
+				return path, fmt.Errorf("unclosed '[' in %q", expr)
+			}
+			inner := strings.TrimSpace(expr[1:n])
+			expr = expr[n+1:]
+
+			if inner == "*" {
+				path.segments = append(path.segments, jpSegment{wildcard: true})
+				continue
+			}
+			if quoted, err := strconv.Unquote(strings.ReplaceAll(inner, "'", `"`)); err == nil {
+				path.segments = append(path.segments, jpSegment{field: quoted})
+				continue
+			}
+			index, err := strconv.Atoi(inner)
+			if err != nil {
+				return path, fmt.Errorf("unsupported subscript [%s]", inner)
+			}
+			path.segments = append(path.segments, jpSegment{index: index, isIndex: true})
+
+		default:
+			return path, fmt.Errorf("unexpected %q in expression", expr)
+		}
+	}
+
+	return path, nil
+}
+
+func (jp *jsonPath) execute(w io.Writer, doc interface{}) error {
+	return executeNodes(w, jp.nodes, doc, doc)
+}
+
+func executeNodes(w io.Writer, nodes []jpNode, root, current interface{}) error {
+	for _, node := range nodes {
+		switch node.kind {
+		case jpText:
+			io.WriteString(w, node.text)
+
+		case jpExpr:
+			values := node.path.eval(root, current)
+			for i, value := range values {
+				if i > 0 {
+					io.WriteString(w, " ")
+				}
+				io.WriteString(w, formatValue(value))
+			}
+
+		case jpRange:
+			for _, value := range node.path.eval(root, current) {
+				if err := executeNodes(w, node.children, root, value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// eval returns every value the path selects. Missing fields and indexes
+// select nothing.
+func (p jpPath) eval(root, current interface{}) []interface{} {
+	values := []interface{}{current}
+	if p.fromRoot {
+		values = []interface{}{root}
+	}
+
+	for _, segment := range p.segments {
+		var next []interface{}
+		for _, value := range values {
+			switch {
+			case segment.wildcard:
+				switch v := value.(type) {
+				case []interface{}:
+					next = append(next, v...)
+				case map[string]interface{}:
+					for _, key := range sortedKeys(v) {
+						next = append(next, v[key])
+					}
+				}
+
+			case segment.isIndex:
+				if v, ok := value.([]interface{}); ok {
+					index := segment.index
+					if index < 0 {
+						index += len(v)
+					}
+					if index >= 0 && index < len(v) {
+						next = append(next, v[index])
+					}
+				}
+
+			default:
+				if v, ok := value.(map[string]interface{}); ok {
+					if field, ok := v[segment.field]; ok {
+						next = append(next, field)
+					}
+				}
+			}
+		}
+		values = next
+	}
+
+	return values
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+	return fmt.Sprint(value)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	// map の順序は不定なので出力を安定させる
+	sort.Strings(keys)
+	return keys
+}
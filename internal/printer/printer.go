@@ -0,0 +1,248 @@
+// Package printer writes lists of items in the output formats shared by
+// slakctl's list commands: json, yaml, csv, tsv, table, wide,
+// go-template=<template> and jsonpath=<expression>.
+//
+// Templates and JSONPath expressions are evaluated against the same
+// document -o json prints, a JSON array of the items, so field names are
+// the JSON names (e.g. "num_members"). Templates can use TemplateFuncs.
+// Template formats single messages and files the same way for --format.
+package printer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kind is an output format.
+type Kind string
+
+const (
+	JSON       Kind = "json"
+	YAML       Kind = "yaml"
+	CSV        Kind = "csv"
+	TSV        Kind = "tsv"
+	Table      Kind = "table"
+	Wide       Kind = "wide"
+	GoTemplate Kind = "go-template"
+	JSONPath   Kind = "jsonpath"
+)
+
+// Formats lists the accepted -o values for help texts.
+const Formats = "json|yaml|csv|tsv|table|wide|go-template=...|jsonpath=..."
+
+// Format is a parsed -o value.
+type Format struct {
+	Kind Kind
+	// Arg is the template or expression of go-template and jsonpath.
+	Arg string
+}
+
+// ParseFormat parses an -o value such as "yaml" or
+// "go-template={{range .}}{{.name}}{{end}}". Templates and expressions are
+// checked here so that mistakes are reported before any request is made.
+func ParseFormat(value string) (Format, error) {
+	name, arg, hasArg := strings.Cut(value, "=")
+
+	switch kind := Kind(name); kind {
+	case JSON, YAML, CSV, TSV, Table, Wide:
+		if hasArg {
+			return Format{}, fmt.Errorf("output format %s does not take an argument", name)
+		}
+		return Format{Kind: kind}, nil
+
+	case GoTemplate:
+		if arg == "" {
+			return Format{}, fmt.Errorf("go-template requires a template, e.g. go-template='{{range .}}{{.id}}{{\"\\n\"}}{{end}}'")
+		}
+		if _, err := template.New("output").Funcs(TemplateFuncs).Parse(arg); err != nil {
+			return Format{}, fmt.Errorf("invalid go-template: %w", err)
+		}
+		return Format{Kind: kind, Arg: arg}, nil
+
+	case JSONPath:
+		if arg == "" {
+			return Format{}, fmt.Errorf("jsonpath requires an expression, e.g. jsonpath='{[*].id}'")
+		}
+		if _, err := parseJSONPath(arg); err != nil {
+			return Format{}, fmt.Errorf("invalid jsonpath: %w", err)
+		}
+		return Format{Kind: kind, Arg: arg}, nil
+	}
+
+	return Format{}, fmt.Errorf("invalid output format %q: use %s", value, Formats)
+}
+
+// Column is a column of table, wide, csv and tsv output.
+type Column[T any] struct {
+	Header string
+	// Wide columns are only shown by the wide format and in csv and tsv.
+	Wide  bool
+	Value func(T) string
+}
+
+// Print writes items to w in format. columns are used by the tabular
+// formats.
+func Print[T any](w io.Writer, format Format, items []T, columns []Column[T]) error {
+	if items == nil {
+		items = []T{}
+	}
+
+	switch format.Kind {
+	case JSON:
+		data, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+
+	case YAML:
+		doc, err := toDocument(items)
+		if err != nil {
+			return err
+		}
+		data, err := yaml.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("failed to marshal YAML: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+
+	case CSV, TSV:
+		writer := csv.NewWriter(w)
+		if format.Kind == TSV {
+			writer.Comma = '\t'
+		}
+		writer.Write(headers(columns, true))
+		for _, item := range items {
+			writer.Write(row(item, columns, true))
+		}
+		writer.Flush()
+		return writer.Error()
+
+	case Table, Wide:
+		wide := format.Kind == Wide
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(headers(columns, wide), "\t"))
+		for _, item := range items {
+			fmt.Fprintln(tw, strings.Join(cleanCells(row(item, columns, wide)), "\t"))
+		}
+		return tw.Flush()
+
+	case GoTemplate:
+		tmpl, err := template.New("output").Funcs(TemplateFuncs).Parse(format.Arg)
+		if err != nil {
+			return fmt.Errorf("invalid go-template: %w", err)
+		}
+		doc, err := toDocument(items)
+		if err != nil {
+			return err
+		}
+		if err := tmpl.Execute(w, doc); err != nil {
+			return fmt.Errorf("failed to execute go-template: %w", err)
+		}
+		return nil
+
+	case JSONPath:
+		jp, err := parseJSONPath(format.Arg)
+		if err != nil {
+			return fmt.Errorf("invalid jsonpath: %w", err)
+		}
+		doc, err := toDocument(items)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := jp.execute(&buf, doc); err != nil {
+			return fmt.Errorf("failed to evaluate jsonpath: %w", err)
+		}
+		// kubectl と同じく末尾に改行が無ければ補う
+		if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		_, err = w.Write(buf.Bytes())
+		return err
+	}
+
+	return fmt.Errorf("unsupported output format: %s", format.Kind)
+}
+
+// toDocument converts items to the generic JSON representation so that
+// YAML and templates see the JSON field names.
+func toDocument[T any](items []T) (interface{}, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// 大きな整数が float64 で丸められないよう json.Number で受ける
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+	return normalizeNumbers(doc), nil
+}
+
+// normalizeNumbers replaces json.Number with int64 or float64 so that YAML
+// does not quote numbers.
+func normalizeNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = normalizeNumbers(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = normalizeNumbers(value)
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	}
+	return v
+}
+
+func headers[T any](columns []Column[T], wide bool) []string {
+	var result []string
+	for _, column := range columns {
+		if column.Wide && !wide {
+			continue
+		}
+		result = append(result, column.Header)
+	}
+	return result
+}
+
+func row[T any](item T, columns []Column[T], wide bool) []string {
+	var result []string
+	for _, column := range columns {
+		if column.Wide && !wide {
+			continue
+		}
+		result = append(result, column.Value(item))
+	}
+	return result
+}
+
+// cleanCells keeps table cells on one line.
+func cleanCells(cells []string) []string {
+	replacer := strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+	for i, cell := range cells {
+		cells[i] = replacer.Replace(cell)
+	}
+	return cells
+}
@@ -0,0 +1,107 @@
+package printer
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type item struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Members int    `json:"num_members"`
+	Topic   string `json:"topic"`
+}
+
+var items = []item{
+	{ID: "C1", Name: "general", Members: 120, Topic: "Company-wide\nannouncements"},
+	{ID: "C2", Name: "random", Members: 3, Topic: "Anything, really"},
+}
+
+var columns = []Column[item]{
+	{Header: "ID", Value: func(i item) string { return i.ID }},
+	{Header: "NAME", Value: func(i item) string { return i.Name }},
+	{Header: "MEMBERS", Value: func(i item) string { return strconv.Itoa(i.Members) }},
+	{Header: "TOPIC", Wide: true, Value: func(i item) string { return i.Topic }},
+}
+
+func render(t *testing.T, value string) string {
+	t.Helper()
+
+	format, err := ParseFormat(value)
+	if err != nil {
+		t.Fatalf("ParseFormat(%s) returned error: %v", value, err)
+	}
+
+	var buf bytes.Buffer
+	if err := Print(&buf, format, items, columns); err != nil {
+		t.Fatalf("Print(%s) returned error: %v", value, err)
+	}
+	return buf.String()
+}
+
+func TestPrint(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"csv", "ID,NAME,MEMBERS,TOPIC\nC1,general,120,\"Company-wide\nannouncements\"\nC2,random,3,\"Anything, really\"\n"},
+		{"tsv", "ID\tNAME\tMEMBERS\tTOPIC\nC1\tgeneral\t120\t\"Company-wide\nannouncements\"\nC2\trandom\t3\tAnything, really\n"},
+		{"table", "ID   NAME      MEMBERS\nC1   general   120\nC2   random    3\n"},
+		{"wide", "ID   NAME      MEMBERS   TOPIC\nC1   general   120       Company-wide announcements\nC2   random    3         Anything, really\n"},
+		{"yaml", "- id: C1\n  name: general\n  num_members: 120\n  topic: |-\n    Company-wide\n    announcements\n- id: C2\n  name: random\n  num_members: 3\n  topic: Anything, really\n"},
+		{`go-template={{range .}}{{.id}}={{.num_members}} {{end}}`, "C1=120 C2=3 "},
+		{`jsonpath={[*].name}`, "general random\n"},
+		{`jsonpath={range .[*]}{.id}{"\t"}{.name}{"\n"}{end}`, "C1\tgeneral\nC2\trandom\n"},
+		{`jsonpath={[-1].id}`, "C2\n"},
+		{`jsonpath={$[0]['name']}`, "general\n"},
+	}
+
+	for _, test := range tests {
+		if got := render(t, test.format); got != test.expected {
+			t.Errorf("Print(%s) = %q, expected %q", test.format, got, test.expected)
+		}
+	}
+
+	t.Run("should print JSON with field names", func(t *testing.T) {
+		got := render(t, "json")
+		if !strings.Contains(got, `"num_members": 120`) || !strings.HasPrefix(got, "[") {
+			t.Errorf("unexpected JSON: %s", got)
+		}
+	})
+
+	t.Run("should print an empty JSON array for no items", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Print(&buf, Format{Kind: JSON}, []item(nil), columns); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if buf.String() != "[]\n" {
+			t.Errorf("expected empty array, got: %q", buf.String())
+		}
+	})
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, value := range []string{
+		"xml",
+		"json=x",
+		"go-template=",
+		"go-template={{.id",
+		"jsonpath=",
+		"jsonpath={.id",
+		"jsonpath={range .[*]}{.id}",
+		"jsonpath={end}",
+		"jsonpath={id}",
+		"jsonpath={[a]}",
+	} {
+		if _, err := ParseFormat(value); err == nil {
+			t.Errorf("expected error for %s", value)
+		}
+	}
+
+	format, err := ParseFormat("go-template={{.id}}={{.name}}")
+	if err != nil || format.Kind != GoTemplate || format.Arg != "{{.id}}={{.name}}" {
+		t.Errorf("expected template argument to keep '=', got: %+v (%v)", format, err)
+	}
+}
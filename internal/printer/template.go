@@ -0,0 +1,329 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/oppai/slakctl/internal/timefmt"
+	"github.com/oppai/slakctl/pkg/slack"
+)
+
+// Template formats one item at a time for the --format flag of commands
+// that print messages or files. Templates see the item's JSON document, as
+// go-template output does, so fields use their JSON names (e.g.
+// "thread_ts"). Fields absent from the document render as empty strings.
+type Template struct {
+	tmpl *template.Template
+}
+
+var (
+	placeholderPattern = regexp.MustCompile(`\{(\w+)\}`)
+	escapeReplacer     = strings.NewReplacer(`\n`, "\n", `\t`, "\t")
+)
+
+// ParseTemplate parses a --format value. Values containing "{{" are Go
+// templates with TemplateFuncs. Other values use the older {name}
+// placeholders: each one found in placeholders is replaced by its template
+// expression and unknown ones are kept as they are. In both, \n and \t
+// outside actions stand for a newline and a tab.
+func ParseTemplate(text string, placeholders map[string]string) (*Template, error) {
+	if !strings.Contains(text, "{{") {
+		text = placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+			if expr, ok := placeholders[match[1:len(match)-1]]; ok {
+				return expr
+			}
+			return match
+		})
+	}
+
+	tmpl, err := template.New("format").
+		Funcs(TemplateFuncs).
+		Funcs(template.FuncMap{emptyFuncName: empty}).
+		Option("missingkey=zero").
+		Parse(unescape(text))
+	if err != nil {
+		return nil, fmt.Errorf("invalid format: %w", err)
+	}
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			emptyMissing(t.Tree, t.Tree.Root)
+		}
+	}
+	return &Template{tmpl: tmpl}, nil
+}
+
+// Execute renders item.
+func (t *Template) Execute(item any) (string, error) {
+	doc, err := toDocument([]any{item})
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, doc.([]any)[0]); err != nil {
+		return "", fmt.Errorf("failed to execute format: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// emptyFuncName is the function emptyMissing appends to actions. The
+// leading underscore keeps it apart from TemplateFuncs.
+const emptyFuncName = "_empty"
+
+// empty turns the nil of a missing field or JSON null into "", which
+// text/template would print as "<no value>".
+func empty(v any) any {
+	if v == nil {
+		return ""
+	}
+	return v
+}
+
+// emptyMissing pipes every action under node that prints its value
+// through empty.
+func emptyMissing(tree *parse.Tree, node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			emptyMissing(tree, child)
+		}
+	case *parse.ActionNode:
+		// 変数への代入は何も出力しない
+		if len(n.Pipe.Decl) > 0 {
+			return
+		}
+		ident := parse.NewIdentifier(emptyFuncName).SetTree(tree).SetPos(n.Pos)
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{NodeType: parse.NodeCommand, Pos: n.Pos, Args: []parse.Node{ident}})
+	case *parse.IfNode:
+		emptyMissing(tree, n.List)
+		emptyMissing(tree, n.ElseList)
+	case *parse.RangeNode:
+		emptyMissing(tree, n.List)
+		emptyMissing(tree, n.ElseList)
+	case *parse.WithNode:
+		emptyMissing(tree, n.List)
+		emptyMissing(tree, n.ElseList)
+	}
+}
+
+// unescape replaces \n and \t outside template actions, where they would
+// break quoted strings.
+func unescape(text string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(text, "{{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(text[start:], "}}")
+		if end < 0 {
+			break
+		}
+		end += start + 2
+		b.WriteString(escapeReplacer.Replace(text[:start]))
+		b.WriteString(text[start:end])
+		text = text[end:]
+	}
+	b.WriteString(escapeReplacer.Replace(text))
+	return b.String()
+}
+
+// TemplateFuncs are the functions available in --format and go-template
+// templates:
+//
+//	date LAYOUT TIME     formats a Slack timestamp, Unix seconds or time in
+//	                     the --tz time zone with a Go layout ("2006-01-02")
+//	time TIME            formats a time like date, in the --time-format
+//	                     style (relative, iso, unix or a layout)
+//	truncate N TEXT      shortens TEXT to N characters, ending with "…"
+//	upper, lower, trim   change case or trim surrounding white space
+//	json VALUE           encodes VALUE as compact JSON
+//	mention ID           formats a user or conversation ID as a Slack
+//	                     mention (<@U…>, <#C…>)
+//	color NAME TEXT      colors TEXT with ANSI escapes unless NO_COLOR is set
+//	join SEP LIST...     joins the items of one or more lists
+//	size BYTES           formats a byte count (2.0 KB)
+var TemplateFuncs = template.FuncMap{
+	"date":     formatDate,
+	"time":     formatTime,
+	"truncate": truncate,
+	"upper":    func(v any) string { return strings.ToUpper(toString(v)) },
+	"lower":    func(v any) string { return strings.ToLower(toString(v)) },
+	"trim":     func(v any) string { return strings.TrimSpace(toString(v)) },
+	"json":     toJSON,
+	"mention":  mention,
+	"color":    color,
+	"join":     join,
+	"size":     func(v any) string { n, _ := toInt64(v); return FormatSize(n) },
+}
+
+func formatDate(layout string, v any) (string, error) {
+	t, err := toTime("date", v)
+	if err != nil || t.IsZero() {
+		return "", err
+	}
+	return timefmt.Default.In(t).Format(layout), nil
+}
+
+func formatTime(v any) (string, error) {
+	if ts, ok := v.(string); ok {
+		// unix でマイクロ秒まで残せるようタイムスタンプのまま渡す
+		if _, err := slack.ParseTimestamp(ts); err == nil {
+			return timefmt.Default.FormatTS(ts), nil
+		}
+	}
+	t, err := toTime("time", v)
+	if err != nil {
+		return "", err
+	}
+	return timefmt.Default.Format(t), nil
+}
+
+// toTime converts a Slack timestamp, RFC 3339 string, Unix time or
+// time.Time for the date and time functions. Empty values give the zero
+// time.
+func toTime(name string, v any) (time.Time, error) {
+	switch v := v.(type) {
+	case nil:
+		return time.Time{}, nil
+	case time.Time:
+		return v, nil
+	case string:
+		if v == "" {
+			return time.Time{}, nil
+		}
+		// Slack のタイムスタンプ ("1714521600.000100") は秒として扱う
+		if t, err := slack.ParseTimestamp(v); err == nil {
+			return t, nil
+		}
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("%s: invalid time %q", name, v)
+	}
+
+	n, ok := toInt64(v)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%s: invalid time %v", name, v)
+	}
+	if n == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(n, 0), nil
+}
+
+func truncate(n int, v any) string {
+	s := toString(v)
+	runes := []rune(s)
+	if n < 1 || len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+func toJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("json: %w", err)
+	}
+	return string(data), nil
+}
+
+func mention(v any) string {
+	id := toString(v)
+	switch {
+	case id == "":
+		return ""
+	case slack.IsUserID(id):
+		return "<@" + id + ">"
+	case slack.IsConversationID(id):
+		return "<#" + id + ">"
+	}
+	return "@" + strings.TrimPrefix(id, "@")
+}
+
+var colorCodes = map[string]string{
+	"black": "30", "red": "31", "green": "32", "yellow": "33",
+	"blue": "34", "magenta": "35", "cyan": "36", "white": "37",
+	"gray": "90", "bold": "1", "dim": "2", "underline": "4",
+}
+
+func color(name string, v any) (string, error) {
+	s := toString(v)
+	code, ok := colorCodes[name]
+	if !ok {
+		return "", fmt.Errorf("color: unknown color %q", name)
+	}
+	// https://no-color.org/
+	if os.Getenv("NO_COLOR") != "" {
+		return s, nil
+	}
+	return "\x1b[" + code + "m" + s + "\x1b[0m", nil
+}
+
+func join(sep string, lists ...any) string {
+	var parts []string
+	for _, list := range lists {
+		v := reflect.ValueOf(list)
+		if v.Kind() != reflect.Slice {
+			if list != nil {
+				parts = append(parts, fmt.Sprint(list))
+			}
+			continue
+		}
+		for i := range v.Len() {
+			parts = append(parts, fmt.Sprint(v.Index(i).Interface()))
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// toString converts the arguments of the string functions, treating the
+// nil of a missing field or JSON null as "".
+func toString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	return fmt.Sprint(v)
+}
+
+func toInt64(v any) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		return int64(v), true
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// FormatSize formats a byte count as B, KB or MB.
+func FormatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", size)
+}
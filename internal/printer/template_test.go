@@ -0,0 +1,122 @@
+package printer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type message struct {
+	User     string            `json:"user"`
+	Username string            `json:"username"`
+	Text     string            `json:"text"`
+	TS       string            `json:"ts"`
+	ThreadTS string            `json:"thread_ts,omitempty"`
+	Channel  map[string]string `json:"channel"`
+	Files    []string          `json:"files,omitempty"`
+}
+
+var placeholders = map[string]string{
+	"channel": "{{or .channel.name .channel.id}}",
+	"user":    "{{or .username .user}}",
+	"text":    "{{trim .text}}",
+}
+
+func execute(t *testing.T, text string, item any) string {
+	t.Helper()
+
+	tmpl, err := ParseTemplate(text, placeholders)
+	if err != nil {
+		t.Fatalf("ParseTemplate(%s) returned error: %v", text, err)
+	}
+	output, err := tmpl.Execute(item)
+	if err != nil {
+		t.Fatalf("Execute(%s) returned error: %v", text, err)
+	}
+	return output
+}
+
+func TestTemplate(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	ts := time.Date(2024, 5, 1, 9, 30, 0, 0, time.Local).Unix()
+	msg := message{
+		User:    "U024BE7LH",
+		Text:    "  deploy finished successfully  ",
+		TS:      fmt.Sprintf("%d.000100", ts),
+		Channel: map[string]string{"id": "C024BE91L", "name": "ops"},
+	}
+
+	t.Run("should keep placeholders working", func(t *testing.T) {
+		output := execute(t, `#{channel}\t{user}: {text} {unknown}`, msg)
+		if output != "#ops\tU024BE7LH: deploy finished successfully {unknown}" {
+			t.Errorf("unexpected output: %q", output)
+		}
+	})
+
+	t.Run("should expose fields by JSON name", func(t *testing.T) {
+		output := execute(t, `{{.channel.id}} [{{.thread_ts}}] {{.files}}`, msg)
+		if output != "C024BE91L [] " {
+			t.Errorf("expected absent fields to be empty, got: %q", output)
+		}
+	})
+
+	t.Run("should keep text that reads <no value>", func(t *testing.T) {
+		literal := msg
+		literal.Text = "<no value>"
+		output := execute(t, `{{.text}}|{{.missing}}|{{with .channel}}{{.topic}}{{end}}|{{define "x"}}{{.nope}}{{end}}{{template "x" .}}`, literal)
+		if output != "<no value>|||" {
+			t.Errorf("unexpected output: %q", output)
+		}
+	})
+
+	t.Run("should pass absent fields to helpers as empty", func(t *testing.T) {
+		output := execute(t, `[{{upper .subtype}}|{{trim .thread_ts}}|{{truncate 20 .thread_ts}}|{{mention .bot_id}}|{{color "red" .missing}}]`, msg)
+		if output != "[||||\x1b[31m\x1b[0m]" {
+			t.Errorf("unexpected output: %q", output)
+		}
+	})
+
+	t.Run("should provide helpers", func(t *testing.T) {
+		tests := map[string]string{
+			`{{date "2006-01-02 15:04" .ts}}`:                               "2024-05-01 09:30",
+			`{{truncate 10 (trim .text)}}`:                                  "deploy fi…",
+			`{{upper .channel.name}}`:                                       "OPS",
+			`{{json .channel}}`:                                             `{"id":"C024BE91L","name":"ops"}`,
+			`{{mention .user}} {{mention .channel.id}} {{mention "alice"}}`: "<@U024BE7LH> <#C024BE91L> @alice",
+			`{{color "red" .channel.name}}`:                                 "\x1b[31mops\x1b[0m",
+			`{{join ", " "a" .channel.name}}`:                               "a, ops",
+			`{{size 2048}}`:                                                 "2.0 KB",
+			`{{printf "%-5s|" .channel.name}}`:                              "ops  |",
+		}
+		for text, want := range tests {
+			if output := execute(t, text, msg); output != want {
+				t.Errorf("%s: expected %q, got: %q", text, want, output)
+			}
+		}
+	})
+
+	t.Run("should respect NO_COLOR", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		if output := execute(t, `{{color "red" "x"}}`, msg); output != "x" {
+			t.Errorf("unexpected output: %q", output)
+		}
+	})
+
+	t.Run("should unescape outside actions only", func(t *testing.T) {
+		output := execute(t, `{{.channel.name}}\n{{"\n" | len}}`, msg)
+		if output != "ops\n1" {
+			t.Errorf("unexpected output: %q", output)
+		}
+	})
+
+	t.Run("should report errors", func(t *testing.T) {
+		if _, err := ParseTemplate(`{{.text`, placeholders); err == nil || !strings.Contains(err.Error(), "invalid format") {
+			t.Errorf("expected parse error, got: %v", err)
+		}
+		tmpl, _ := ParseTemplate(`{{color "plaid" .text}}`, placeholders)
+		if _, err := tmpl.Execute(msg); err == nil || !strings.Contains(err.Error(), "unknown color") {
+			t.Errorf("expected execution error, got: %v", err)
+		}
+	})
+}
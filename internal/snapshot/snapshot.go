@@ -0,0 +1,165 @@
+// Package snapshot saves the channel directory of a workspace to disk and
+// compares snapshots taken at different times.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/oppai/slakctl/pkg/slack"
+)
+
+// Snapshot is the channel directory at one point in time. TeamID
+// identifies the workspace; Team is its name when the snapshot was taken.
+type Snapshot struct {
+	TakenAt  time.Time       `json:"taken_at"`
+	TeamID   string          `json:"team_id,omitempty"`
+	Team     string          `json:"team,omitempty"`
+	Channels []slack.Channel `json:"channels"`
+}
+
+// FileName returns the name Save gives a snapshot taken at t. Names sort
+// in the order snapshots were taken.
+func FileName(t time.Time) string {
+	return "channels-" + t.UTC().Format("20060102-150405.000") + ".json"
+}
+
+// Save writes s to dir under FileName and returns the path. It does not
+// overwrite an existing snapshot.
+func Save(dir string, s *Snapshot) (string, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	path := filepath.Join(dir, FileName(s.TakenAt))
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return path, nil
+}
+
+// Load reads a snapshot written by Save.
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// ChangeType is the kind of a Change.
+type ChangeType string
+
+const (
+	Added   ChangeType = "added"
+	Removed ChangeType = "removed"
+	Changed ChangeType = "changed"
+)
+
+// FieldChange is an attribute that differs between two snapshots.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Change describes how one channel differs between two snapshots.
+type Change struct {
+	Type ChangeType `json:"type"`
+	ID   string     `json:"id"`
+	Name string     `json:"name"`
+	// OldName is set when the channel was renamed.
+	OldName string        `json:"old_name,omitempty"`
+	Fields  []FieldChange `json:"fields,omitempty"`
+	// Channel is the channel in the newer snapshot, or in the older one
+	// if it was removed.
+	Channel slack.Channel `json:"channel"`
+}
+
+// fields are the attributes Diff compares. Member counts are left out as
+// they change all the time.
+var fields = []struct {
+	name  string
+	value func(slack.Channel) string
+}{
+	{"name", func(ch slack.Channel) string { return ch.Name }},
+	{"is_archived", func(ch slack.Channel) string { return strconv.FormatBool(ch.IsArchived) }},
+	{"is_private", func(ch slack.Channel) string { return strconv.FormatBool(ch.IsPrivate) }},
+	{"is_shared", func(ch slack.Channel) string { return strconv.FormatBool(ch.IsShared) }},
+	{"is_ext_shared", func(ch slack.Channel) string { return strconv.FormatBool(ch.IsExtShared) }},
+	{"topic", func(ch slack.Channel) string { return ch.Topic.Value }},
+	{"purpose", func(ch slack.Channel) string { return ch.Purpose.Value }},
+}
+
+// Diff compares channels by ID, so renamed channels show up as changes
+// rather than as a removal and an addition. Changes are ordered by type
+// (added, removed, changed) and then by name.
+func Diff(older, newer *Snapshot) []Change {
+	before := make(map[string]slack.Channel, len(older.Channels))
+	for _, ch := range older.Channels {
+		before[ch.ID] = ch
+	}
+	after := make(map[string]bool, len(newer.Channels))
+
+	var changes []Change
+	for _, ch := range newer.Channels {
+		after[ch.ID] = true
+
+		old, ok := before[ch.ID]
+		if !ok {
+			changes = append(changes, Change{Type: Added, ID: ch.ID, Name: ch.Name, Channel: ch})
+			continue
+		}
+
+		change := Change{Type: Changed, ID: ch.ID, Name: ch.Name, Channel: ch}
+		for _, field := range fields {
+			if o, n := field.value(old), field.value(ch); o != n {
+				change.Fields = append(change.Fields, FieldChange{Field: field.name, Old: o, New: n})
+			}
+		}
+		if old.Name != ch.Name {
+			change.OldName = old.Name
+		}
+		if len(change.Fields) > 0 {
+			changes = append(changes, change)
+		}
+	}
+
+	for _, ch := range older.Channels {
+		if !after[ch.ID] {
+			changes = append(changes, Change{Type: Removed, ID: ch.ID, Name: ch.Name, Channel: ch})
+		}
+	}
+
+	order := map[ChangeType]int{Added: 0, Removed: 1, Changed: 2}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Type != changes[j].Type {
+			return order[changes[i].Type] < order[changes[j].Type]
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
@@ -0,0 +1,91 @@
+package snapshot
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oppai/slakctl/pkg/slack"
+)
+
+func TestSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	s := &Snapshot{
+		TakenAt:  time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC),
+		TeamID:   "T1",
+		Team:     "Acme",
+		Channels: []slack.Channel{{ID: "C1", Name: "general", NumMembers: 10}},
+	}
+
+	path, err := Save(filepath.Join(dir, "snapshots"), s)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if filepath.Base(path) != "channels-20240501-093000.000.json" {
+		t.Errorf("unexpected file name: %s", path)
+	}
+	if _, err := Save(filepath.Join(dir, "snapshots"), s); err == nil {
+		t.Error("expected error instead of overwriting a snapshot")
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !loaded.TakenAt.Equal(s.TakenAt) || loaded.TeamID != "T1" || loaded.Team != "Acme" || !reflect.DeepEqual(loaded.Channels, s.Channels) {
+		t.Errorf("unexpected snapshot: %+v", loaded)
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected error for missing snapshot")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	older := &Snapshot{Channels: []slack.Channel{
+		{ID: "C1", Name: "general", NumMembers: 10},
+		{ID: "C2", Name: "old-name", Topic: slack.ChannelTopic{Value: "hi"}},
+		{ID: "C3", Name: "gone"},
+		{ID: "C4", Name: "partners"},
+	}}
+	newer := &Snapshot{Channels: []slack.Channel{
+		{ID: "C1", Name: "general", NumMembers: 12},
+		{ID: "C2", Name: "new-name", Topic: slack.ChannelTopic{Value: "hi"}, IsArchived: true},
+		{ID: "C4", Name: "partners", IsShared: true, IsExtShared: true},
+		{ID: "C5", Name: "brand-new"},
+	}}
+
+	changes := Diff(older, newer)
+
+	if len(changes) != 4 {
+		t.Fatalf("expected 4 changes, got: %+v", changes)
+	}
+
+	t.Run("should report additions and removals first", func(t *testing.T) {
+		if changes[0].Type != Added || changes[0].ID != "C5" {
+			t.Errorf("expected C5 to be added, got: %+v", changes[0])
+		}
+		if changes[1].Type != Removed || changes[1].Name != "gone" {
+			t.Errorf("expected #gone to be removed, got: %+v", changes[1])
+		}
+	})
+
+	t.Run("should track renames by ID", func(t *testing.T) {
+		renamed := changes[2]
+		if renamed.Type != Changed || renamed.ID != "C2" || renamed.Name != "new-name" || renamed.OldName != "old-name" {
+			t.Errorf("unexpected change: %+v", renamed)
+		}
+		want := []FieldChange{{"name", "old-name", "new-name"}, {"is_archived", "false", "true"}}
+		if !reflect.DeepEqual(renamed.Fields, want) {
+			t.Errorf("unexpected fields: %+v", renamed.Fields)
+		}
+	})
+
+	t.Run("should report sharing and ignore member counts", func(t *testing.T) {
+		shared := changes[3]
+		if shared.ID != "C4" || shared.OldName != "" || len(shared.Fields) != 2 || shared.Fields[1].Field != "is_ext_shared" {
+			t.Errorf("unexpected change: %+v", shared)
+		}
+	})
+}
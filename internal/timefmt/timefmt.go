@@ -0,0 +1,253 @@
+// Package timefmt converts Slack timestamps to display times and parses
+// the time flags of commands, both in a configurable time zone.
+//
+// Commands format every time they print through Default, which the --tz
+// and --time-format flags configure, so text output, --format templates
+// and table and CSV columns agree.
+package timefmt
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oppai/slakctl/pkg/slack"
+)
+
+// Styles accepted by Formatter.Layout besides Go layouts.
+const (
+	// Relative prints the time relative to now, such as "3h ago".
+	Relative = "relative"
+	// ISO prints RFC 3339 with the zone offset.
+	ISO = "iso"
+	// Unix prints seconds since the epoch. Slack timestamps are printed
+	// as they are, keeping their microseconds.
+	Unix = "unix"
+)
+
+// DefaultLayout is used when Formatter.Layout is empty.
+const DefaultLayout = time.DateTime
+
+// Formatter formats times in a time zone and style.
+type Formatter struct {
+	// Location defaults to time.Local.
+	Location *time.Location
+	// Layout is Relative, ISO, Unix or a Go layout such as
+	// "2006-01-02 15:04". Defaults to DefaultLayout.
+	Layout string
+	// Now returns the reference time of Relative. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Default is the Formatter configured by the --tz and --time-format flags.
+var Default = &Formatter{}
+
+// Configure sets the time zone and layout of f from flag values, as
+// validated by LoadLocation and ParseLayout.
+func (f *Formatter) Configure(tz, layout string) error {
+	location, err := LoadLocation(tz)
+	if err != nil {
+		return err
+	}
+	if layout, err = ParseLayout(layout); err != nil {
+		return err
+	}
+	f.Location = location
+	f.Layout = layout
+	return nil
+}
+
+// In returns t in the formatter's time zone.
+func (f *Formatter) In(t time.Time) time.Time {
+	if f.Location == nil {
+		return t.Local()
+	}
+	return t.In(f.Location)
+}
+
+func (f *Formatter) now() time.Time {
+	if f.Now != nil {
+		return f.Now()
+	}
+	return time.Now()
+}
+
+// Format formats t in the formatter's time zone and style. The zero time
+// formats as an empty string.
+func (f *Formatter) Format(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	switch f.Layout {
+	case Relative:
+		return relative(f.now().Sub(t))
+	case ISO:
+		return f.In(t).Format(time.RFC3339)
+	case Unix:
+		return strconv.FormatInt(t.Unix(), 10)
+	case "":
+		return f.In(t).Format(DefaultLayout)
+	}
+	return f.In(t).Format(f.Layout)
+}
+
+// FormatTS formats a Slack timestamp such as "1712345678.123456". Values
+// that are not timestamps are returned unchanged.
+func (f *Formatter) FormatTS(ts string) string {
+	if ts == "" || f.Layout == Unix {
+		return ts
+	}
+	t, err := slack.ParseTimestamp(ts)
+	if err != nil {
+		return ts
+	}
+	return f.Format(t)
+}
+
+// FormatUnix formats seconds since the epoch, such as the created field of
+// channels and files. 0 formats as an empty string.
+func (f *Formatter) FormatUnix(sec int64) string {
+	if sec == 0 {
+		return ""
+	}
+	return f.Format(time.Unix(sec, 0))
+}
+
+// relative formats d, the time elapsed since an event, in the largest
+// whole unit.
+func relative(d time.Duration) string {
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var span string
+	switch day := 24 * time.Hour; {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		span = fmt.Sprintf("%dm", d/time.Minute)
+	case d < 2*day:
+		span = fmt.Sprintf("%dh", d/time.Hour)
+	case d < 60*day:
+		span = fmt.Sprintf("%dd", d/day)
+	case d < 730*day:
+		span = fmt.Sprintf("%dmo", d/(30*day))
+	default:
+		span = fmt.Sprintf("%dy", d/(365*day))
+	}
+
+	if future {
+		return "in " + span
+	}
+	return span + " ago"
+}
+
+// LoadLocation resolves a --tz value: "" or "local" for the system time
+// zone, "UTC", an IANA name such as "Asia/Tokyo", or a fixed offset such as
+// "+09:00".
+func LoadLocation(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "", "local":
+		return time.Local, nil
+	case "utc", "z":
+		return time.UTC, nil
+	}
+
+	if t, err := time.Parse("-07:00", name); err == nil {
+		_, offset := t.Zone()
+		return time.FixedZone(name, offset), nil
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: use local, UTC, a name like Asia/Tokyo, or an offset like +09:00", name)
+	}
+	return location, nil
+}
+
+// ParseLayout validates a --time-format value: relative, iso, unix or a Go
+// layout. "" selects DefaultLayout.
+func ParseLayout(layout string) (string, error) {
+	switch strings.ToLower(layout) {
+	case "":
+		return DefaultLayout, nil
+	case Relative, ISO, Unix:
+		return strings.ToLower(layout), nil
+	}
+
+	// 日時の要素を含まないレイアウトは常に同じ文字列になってしまう
+	sample := time.Date(1999, 12, 31, 23, 59, 58, 0, time.UTC)
+	if sample.Format(layout) == layout {
+		return "", fmt.Errorf("invalid time format %q: use relative, iso, unix, or a Go layout like \"2006-01-02 15:04\"", layout)
+	}
+	return layout, nil
+}
+
+var (
+	parseLayouts = []string{
+		time.RFC3339,
+		"2006-01-02T15:04",
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04",
+		"2006-01-02",
+	}
+	slackTimestamp = regexp.MustCompile(`^\d{9,}(\.\d+)?$`)
+)
+
+// Parse parses a time given on the command line. It accepts a duration
+// before now such as "30m", "2h", "3d" or "1w"; a date or date-time in
+// now's time zone; RFC 3339; or a Slack timestamp or Unix time such as
+// "1712345678.123456". An empty value gives the zero time.
+func Parse(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if d, ok := parseRelativeDuration(value); ok {
+		return now.Add(-d), nil
+	}
+
+	for _, layout := range parseLayouts {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	if slackTimestamp.MatchString(value) {
+		return slack.ParseTimestamp(value)
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q: use a duration like 2h or 3d, a date like 2024-05-01, or a Slack timestamp", value)
+}
+
+// parseRelativeDuration extends time.ParseDuration with "d" (days) and "w"
+// (weeks) units.
+func parseRelativeDuration(value string) (time.Duration, bool) {
+	if len(value) < 2 {
+		return 0, false
+	}
+
+	unit := value[len(value)-1]
+	if unit == 'd' || unit == 'w' {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil || n < 0 {
+			return 0, false
+		}
+		days := n
+		if unit == 'w' {
+			days *= 7
+		}
+		return time.Duration(days) * 24 * time.Hour, true
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, false
+	}
+	return d, true
+}
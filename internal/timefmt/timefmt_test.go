@@ -0,0 +1,120 @@
+package timefmt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatter(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	ts := "1714554000.000100" // 2024-05-01 09:00:00 UTC
+
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{"", "2024-05-01 18:00:00"},
+		{ISO, "2024-05-01T18:00:00+09:00"},
+		{Unix, ts},
+		{Relative, "9d ago"},
+		{"Jan 2 15:04 MST", "May 1 18:00 JST"},
+	}
+	for _, test := range tests {
+		f := &Formatter{Location: tokyo, Layout: test.layout, Now: func() time.Time { return now }}
+		if got := f.FormatTS(ts); got != test.want {
+			t.Errorf("FormatTS with %q = %q, expected %q", test.layout, got, test.want)
+		}
+	}
+
+	t.Run("should format Unix times", func(t *testing.T) {
+		f := &Formatter{Location: time.UTC, Layout: Unix}
+		if got := f.FormatUnix(1714554000); got != "1714554000" {
+			t.Errorf("unexpected output: %s", got)
+		}
+		if got := f.FormatUnix(0); got != "" {
+			t.Errorf("expected empty output for 0, got: %s", got)
+		}
+	})
+
+	t.Run("should keep values that are not timestamps", func(t *testing.T) {
+		if got := (&Formatter{}).FormatTS("not-a-ts"); got != "not-a-ts" {
+			t.Errorf("unexpected output: %s", got)
+		}
+	})
+}
+
+func TestRelative(t *testing.T) {
+	tests := map[time.Duration]string{
+		30 * time.Second:     "just now",
+		5 * time.Minute:      "5m ago",
+		26 * time.Hour:       "26h ago",
+		3 * 24 * time.Hour:   "3d ago",
+		90 * 24 * time.Hour:  "3mo ago",
+		800 * 24 * time.Hour: "2y ago",
+		-2 * time.Hour:       "in 2h",
+	}
+	for d, want := range tests {
+		if got := relative(d); got != want {
+			t.Errorf("relative(%v) = %q, expected %q", d, got, want)
+		}
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	f := &Formatter{}
+	if err := f.Configure("+05:30", "RELATIVE"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if _, offset := time.Unix(0, 0).In(f.Location).Zone(); offset != 5*60*60+30*60 || f.Layout != Relative {
+		t.Errorf("unexpected formatter: %+v", f)
+	}
+
+	for _, tz := range []string{"", "local", "UTC", "-03:00"} {
+		if _, err := LoadLocation(tz); err != nil {
+			t.Errorf("LoadLocation(%s) returned error: %v", tz, err)
+		}
+	}
+
+	if err := f.Configure("Mars/Olympus", ""); err == nil || !strings.Contains(err.Error(), "invalid time zone") {
+		t.Errorf("expected time zone error, got: %v", err)
+	}
+	if err := f.Configure("UTC", "yesterday"); err == nil || !strings.Contains(err.Error(), "invalid time format") {
+		t.Errorf("expected time format error, got: %v", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, tokyo)
+
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"", time.Time{}},
+		{"3d", now.Add(-3 * 24 * time.Hour)},
+		{"2024-05-01", time.Date(2024, 5, 1, 0, 0, 0, 0, tokyo)},
+		{"2024-05-01 09:30", time.Date(2024, 5, 1, 9, 30, 0, 0, tokyo)},
+		{"2024-05-01T09:30:00Z", time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)},
+		{"1714554000.000100", time.Unix(1714554000, 100000)},
+		{"1714554000", time.Unix(1714554000, 0)},
+	}
+	for _, test := range tests {
+		got, err := Parse(test.input, now)
+		if err != nil {
+			t.Errorf("Parse(%s) returned error: %v", test.input, err)
+			continue
+		}
+		if !got.Equal(test.expected) {
+			t.Errorf("Parse(%s) = %v, expected %v", test.input, got, test.expected)
+		}
+	}
+
+	for _, input := range []string{"yesterday", "-2h", "2024-13-01", "12345"} {
+		if _, err := Parse(input, now); err == nil {
+			t.Errorf("expected error for %s", input)
+		}
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"os"
 
-	"slakctl/cmd"
+	"github.com/oppai/slakctl/cmd"
 )
 
 func main() {
 	if err := cmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		if hint := cmd.Hint(err); hint != "" {
+			fmt.Fprintf(os.Stderr, "Hint: %s\n", hint)
+		}
+		os.Exit(cmd.ExitCode(err))
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,37 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+)
+
+// TestAuth is TestAuthContext with context.Background.
+func (c *Client) TestAuth() error {
+	return c.TestAuthContext(context.Background())
+}
+
+// TestAuthContext checks that the client's token is valid with auth.test.
+func (c *Client) TestAuthContext(ctx context.Context) error {
+	_, err := c.AuthTestContext(ctx)
+	return err
+}
+
+// AuthIdentity describes the workspace and user a token belongs to.
+type AuthIdentity struct {
+	URL    string `json:"url"`
+	Team   string `json:"team"`
+	User   string `json:"user"`
+	TeamID string `json:"team_id"`
+	UserID string `json:"user_id"`
+	BotID  string `json:"bot_id,omitempty"`
+}
+
+// AuthTestContext calls auth.test and returns who the token belongs to.
+func (c *Client) AuthTestContext(ctx context.Context) (*AuthIdentity, error) {
+	var identity AuthIdentity
+	if err := c.call(ctx, "GET", "auth.test", nil, &identity); err != nil {
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+
+	return &identity, nil
+}
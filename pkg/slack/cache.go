@@ -0,0 +1,49 @@
+package slack
+
+import (
+	"context"
+)
+
+// Cache stores the results of directory listings between calls. Get
+// reports whether a fresh entry for key was found and decodes it into v;
+// expiry is up to the implementation. Keys are short names such as
+// "users" or "channels_public_channel". Invalidate drops every entry whose
+// key starts with prefix; the client calls it after changing channels.
+type Cache interface {
+	Get(key string, v interface{}) (bool, error)
+	Set(key string, v interface{}) error
+	Invalidate(prefix string) error
+}
+
+// cached returns the cached value for key, or calls fetch and stores its
+// result. Cache failures are not fatal; the data is fetched instead. hit
+// reports whether the value came from the cache.
+func cached[T any](ctx context.Context, c *Client, key string, fetch func(context.Context) (T, error)) (v T, hit bool, err error) {
+	if c.cache != nil {
+		if ok, err := c.cache.Get(key, &v); err == nil && ok {
+			return v, true, nil
+		}
+	}
+
+	v, err = fetch(ctx)
+	if err != nil {
+		return v, false, err
+	}
+
+	if c.cache != nil {
+		// 書き込みに失敗しても取得結果はそのまま返す
+		c.cache.Set(key, v)
+	}
+	return v, false, nil
+}
+
+// invalidateChannels drops cached channel lists after a channel changed.
+func (c *Client) invalidateChannels() {
+	c.invalidate("channels")
+}
+
+func (c *Client) invalidate(prefix string) {
+	if c.cache != nil {
+		c.cache.Invalidate(prefix)
+	}
+}
@@ -0,0 +1,119 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// memoryCache は JSON でエンコードして保持するテスト用の Cache
+type memoryCache map[string][]byte
+
+func (m memoryCache) Get(key string, v interface{}) (bool, error) {
+	data, ok := m[key]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+func (m memoryCache) Set(key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	m[key] = data
+	return err
+}
+
+func (m memoryCache) Invalidate(prefix string) error {
+	for key := range m {
+		if strings.HasPrefix(key, prefix) {
+			delete(m, key)
+		}
+	}
+	return nil
+}
+
+func TestClientCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":       true,
+			"channels": []map[string]interface{}{{"id": "C1", "name": "general"}},
+		})
+	}))
+	defer server.Close()
+
+	cache := memoryCache{}
+	client := NewClient("test-token", WithBaseURL(server.URL), WithRateLimiter(nil), WithCache(cache))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		channels, err := client.ListChannelsContext(ctx, ListChannelsOptions{AllChannels: true})
+		if err != nil || len(channels) != 1 || channels[0].Name != "general" {
+			t.Fatalf("expected cached channel list, got: %+v (%v)", channels, err)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("expected one request, got: %d", requests)
+	}
+
+	t.Run("should key listings by options", func(t *testing.T) {
+		if _, err := client.ListChannelsContext(ctx, ListChannelsOptions{AllChannels: true, Types: []string{"private_channel"}}); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if requests != 2 {
+			t.Errorf("expected a new request for other types, got: %d", requests)
+		}
+		if _, ok := cache["channels_private_channel"]; !ok {
+			t.Errorf("expected private channel listing to be cached, got keys: %v", cache)
+		}
+	})
+}
+
+func TestResolverRefetchesStaleCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"channels": []map[string]interface{}{
+				{"id": "C1", "name": "general"},
+				{"id": "C2", "name": "new-channel"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	// new-channel が作られる前のキャッシュ
+	cache := memoryCache{}
+	cache.Set("channels_public_channel+private_channel_archived", []Channel{{ID: "C1", Name: "general"}})
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithRateLimiter(nil), WithCache(cache))
+	resolver := NewResolver(client)
+	ctx := context.Background()
+
+	if id, err := resolver.ResolveChannelID(ctx, "#general"); err != nil || id != "C1" || requests != 0 {
+		t.Fatalf("expected cached lookup, got: %s (%v), %d requests", id, err, requests)
+	}
+
+	id, err := resolver.ResolveChannelID(ctx, "#new-channel")
+	if err != nil || id != "C2" {
+		t.Fatalf("expected C2 after refetching, got: %s (%v)", id, err)
+	}
+	if requests != 1 {
+		t.Errorf("expected one request, got: %d", requests)
+	}
+
+	t.Run("should not refetch fresh directories", func(t *testing.T) {
+		if _, err := resolver.ResolveChannelID(ctx, "#missing"); err == nil {
+			t.Fatal("expected not found error")
+		}
+		if requests != 1 {
+			t.Errorf("expected no further requests, got: %d", requests)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+)
+
+// PostMessage is PostMessageContext with context.Background.
+func (c *Client) PostMessage(channelID, text string) error {
+	return c.PostMessageContext(context.Background(), channelID, text)
+}
+
+// PostMessageContext posts text to the conversation channelID with
+// chat.postMessage. Names are not accepted reliably for private channels
+// and some token types; turn them into IDs with a Resolver first.
+func (c *Client) PostMessageContext(ctx context.Context, channelID, text string) error {
+	data := map[string]interface{}{
+		"channel": channelID,
+		"text":    text,
+	}
+
+	if err := c.call(ctx, "POST", "chat.postMessage", data, nil); err != nil {
+		return fmt.Errorf("failed to post message: %w", err)
+	}
+
+	return nil
+}
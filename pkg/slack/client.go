@@ -0,0 +1,261 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Defaults used by NewClient.
+const (
+	DefaultBaseURL        = "https://slack.com/api/"
+	DefaultUserAgent      = "slakctl"
+	DefaultMaxRetries     = 3
+	DefaultRetryBaseDelay = time.Second
+)
+
+// Client is a Slack Web API client bound to a single token. It is safe for
+// concurrent use by multiple goroutines.
+type Client struct {
+	token      string
+	baseURL    string
+	userAgent  string
+	httpClient *http.Client
+	limiter    *RateLimiter
+	maxRetries int
+	retryBase  time.Duration
+	sleep      func(context.Context, time.Duration) error
+	cache      Cache
+}
+
+// Option configures a Client created by NewClient.
+type Option func(*Client)
+
+// WithBaseURL sets the Slack Web API endpoint, e.g. a local stand-in or a
+// GovSlack-style host. Method names are appended to it.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if baseURL == "" {
+			return
+		}
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		c.baseURL = baseURL
+	}
+}
+
+// WithTransport sets the http.RoundTripper used for every request.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		hc := c.cloneHTTPClient()
+		hc.Transport = transport
+		c.httpClient = hc
+	}
+}
+
+// WithHTTPClient replaces the underlying http.Client entirely.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeout sets the timeout for a single HTTP request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		hc := c.cloneHTTPClient()
+		hc.Timeout = timeout
+		c.httpClient = hc
+	}
+}
+
+// cloneHTTPClient copies the http.Client so that options do not change a
+// client given to WithHTTPClient, which may be shared such as
+// http.DefaultClient.
+func (c *Client) cloneHTTPClient() *http.Client {
+	hc := *c.httpClient
+	return &hc
+}
+
+// WithRateLimiter sets the limiter used to pace requests. Pass a shared
+// limiter to coordinate several clients, or nil to disable pacing.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// WithMaxRetries sets how many times a rate limited or transiently failed
+// request is retried before giving up.
+func WithMaxRetries(maxRetries int) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+	}
+}
+
+// WithCache stores directory data (channel, user and emoji lists) in cache
+// so that later runs do not have to fetch it again.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// NewClient returns a client that authenticates with token, which may be a
+// bot (xoxb-) or user (xoxp-) token. By default it talks to slack.com, paces
+// requests with its own RateLimiter and retries failed requests
+// DefaultMaxRetries times.
+func NewClient(token string, opts ...Option) *Client {
+	c := &Client{
+		token:      token,
+		baseURL:    DefaultBaseURL,
+		userAgent:  DefaultUserAgent,
+		httpClient: &http.Client{},
+		limiter:    NewRateLimiter(),
+		maxRetries: DefaultMaxRetries,
+		retryBase:  DefaultRetryBaseDelay,
+		sleep:      sleepContext,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Client) makeRequest(ctx context.Context, method, endpoint string, data interface{}) ([]byte, error) {
+	var payload []byte
+	if data != nil {
+		jsonData, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request data: %w", err)
+		}
+		payload = jsonData
+	}
+
+	apiMethod, _, _ := strings.Cut(endpoint, "?")
+
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx, apiMethod); err != nil {
+				return nil, err
+			}
+		}
+
+		responseBody, resp, err := c.doRequest(ctx, method, endpoint, payload)
+		if err != nil && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if attempt < c.maxRetries {
+			if delay, ok := c.retryDelay(method, resp, err, attempt); ok {
+				if c.limiter != nil && resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+					// 同じメソッドを使う他のリクエストも一緒に待たせる
+					c.limiter.Backoff(apiMethod, delay)
+				} else if err := c.sleep(ctx, delay); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			apiErr := &APIError{Method: apiMethod, StatusCode: resp.StatusCode}
+			if resp.StatusCode == http.StatusTooManyRequests {
+				apiErr.Code = "ratelimited"
+			}
+			return nil, apiErr
+		}
+
+		return responseBody, nil
+	}
+}
+
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, payload []byte) ([]byte, *http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	baseURL := c.baseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+endpoint, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Content-Type", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return responseBody, resp, nil
+}
+
+// call performs a Web API request and decodes the response into v. A
+// response with "ok": false is returned as an *APIError.
+func (c *Client) call(ctx context.Context, method, endpoint string, data, v interface{}) error {
+	body, err := c.makeRequest(ctx, method, endpoint, data)
+	if err != nil {
+		return err
+	}
+
+	var envelope apiResponse
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	apiMethod, _, _ := strings.Cut(endpoint, "?")
+	if apiErr := envelope.err(apiMethod, http.StatusOK); apiErr != nil {
+		return apiErr
+	}
+
+	if v != nil {
+		if err := json.Unmarshal(body, v); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ResponseMetadata is the response_metadata object attached to Web API
+// responses.
+type ResponseMetadata struct {
+	NextCursor string   `json:"next_cursor"`
+	Warnings   []string `json:"warnings,omitempty"`
+	Messages   []string `json:"messages,omitempty"`
+}
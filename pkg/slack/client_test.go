@@ -1,11 +1,14 @@
 package slack
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewClient(t *testing.T) {
@@ -278,4 +281,123 @@ func TestPostMessage(t *testing.T) {
 	if !response.OK {
 		t.Error("expected OK to be true")
 	}
-}
\ No newline at end of file
+}
+
+func TestNewClientOptions(t *testing.T) {
+	t.Run("should use defaults", func(t *testing.T) {
+		client := NewClient("test-token")
+		if client.baseURL != DefaultBaseURL {
+			t.Errorf("expected base URL '%s', got: %s", DefaultBaseURL, client.baseURL)
+		}
+		if client.userAgent != DefaultUserAgent {
+			t.Errorf("expected user agent '%s', got: %s", DefaultUserAgent, client.userAgent)
+		}
+	})
+
+	t.Run("should apply options", func(t *testing.T) {
+		transport := &http.Transport{}
+		client := NewClient("test-token",
+			WithBaseURL("http://localhost:8080/api"),
+			WithTransport(transport),
+			WithUserAgent("custom-agent"),
+			WithTimeout(5*time.Second),
+		)
+
+		if client.baseURL != "http://localhost:8080/api/" {
+			t.Errorf("expected base URL with trailing slash, got: %s", client.baseURL)
+		}
+		if client.httpClient.Transport != transport {
+			t.Error("expected custom transport to be used")
+		}
+		if client.userAgent != "custom-agent" {
+			t.Errorf("expected user agent 'custom-agent', got: %s", client.userAgent)
+		}
+		if client.httpClient.Timeout != 5*time.Second {
+			t.Errorf("expected timeout 5s, got: %v", client.httpClient.Timeout)
+		}
+	})
+
+	t.Run("should not modify a shared HTTP client", func(t *testing.T) {
+		shared := &http.Client{}
+		client := NewClient("test-token",
+			WithHTTPClient(shared),
+			WithTransport(&http.Transport{}),
+			WithTimeout(5*time.Second),
+		)
+
+		if shared.Transport != nil || shared.Timeout != 0 {
+			t.Errorf("expected shared client to be unchanged, got: %+v", shared)
+		}
+		if client.httpClient == shared || client.httpClient.Timeout != 5*time.Second {
+			t.Error("expected options to apply to a copy of the shared client")
+		}
+	})
+}
+
+func TestClientWithBaseURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/auth.test" {
+			t.Errorf("expected path '/api/auth.test', got: %s", r.URL.Path)
+		}
+		if r.Header.Get("User-Agent") != "slakctl-test" {
+			t.Errorf("expected User-Agent 'slakctl-test', got: %s", r.Header.Get("User-Agent"))
+		}
+
+		response := map[string]interface{}{
+			"ok": true,
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token",
+		WithBaseURL(server.URL+"/api"),
+		WithTransport(server.Client().Transport),
+		WithUserAgent("slakctl-test"),
+	)
+
+	if err := client.TestAuth(); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+}
+
+func TestListChannelsContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		response := map[string]interface{}{
+			"ok": true,
+			"channels": []Channel{
+				{ID: "C1234567890", Name: "general"},
+			},
+			"response_metadata": map[string]interface{}{
+				"next_cursor": "next",
+			},
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token",
+		WithBaseURL(server.URL+"/api"),
+		WithTransport(server.Client().Transport),
+	)
+
+	channels, err := client.ListChannelsContext(ctx, ListChannelsOptions{
+		AllChannels: true,
+		// 最初のページを受け取った後にキャンセルする
+		ProgressFunc: func(current, total int) { cancel() },
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+	if len(channels) != 1 {
+		t.Errorf("expected 1 partial channel, got: %d", len(channels))
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got: %d", requests)
+	}
+}
@@ -0,0 +1,214 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strings"
+)
+
+// ListChannelsOptions configures ListChannelsContext and Channels.
+type ListChannelsOptions struct {
+	// AllChannels lifts the default limit of 1000 channels.
+	AllChannels bool
+	// IncludeArchived includes archived channels in the results.
+	IncludeArchived bool
+	// Types selects the conversation types to list: "public_channel",
+	// "private_channel", "im" and "mpim". It defaults to public channels.
+	Types []string
+	// Filter narrows the results. It is applied to the fetched channels, so
+	// without AllChannels only the first 1000 channels are considered.
+	Filter ChannelFilter
+	// Sort orders the results of ListChannelsContext; Reverse flips the
+	// order. Channels streams in API order and ignores both.
+	Sort    ChannelSort
+	Reverse bool
+	// ProgressFunc is called after every page with the number of channels
+	// fetched so far and the limit, or 0 when AllChannels is set.
+	ProgressFunc func(current, total int)
+}
+
+// ListChannels returns up to 1000 unarchived channels.
+func (c *Client) ListChannels() ([]Channel, error) {
+	return c.ListChannelsWithOptions(ListChannelsOptions{})
+}
+
+// ListChannelsWithProgress returns every unarchived channel, reporting
+// progress after each page.
+func (c *Client) ListChannelsWithProgress(progressFunc func(current, total int)) ([]Channel, error) {
+	return c.ListChannelsWithOptions(ListChannelsOptions{
+		AllChannels:  true,
+		ProgressFunc: progressFunc,
+	})
+}
+
+// ListChannelsWithOptions is ListChannelsContext with context.Background.
+func (c *Client) ListChannelsWithOptions(options ListChannelsOptions) ([]Channel, error) {
+	return c.ListChannelsContext(context.Background(), options)
+}
+
+// ListChannelsContext lists channels page by page. If ctx is cancelled
+// between pages, the channels fetched so far are returned together with
+// the context's error. With a Cache configured, complete listings are
+// served from and stored in it.
+func (c *Client) ListChannelsContext(ctx context.Context, options ListChannelsOptions) ([]Channel, error) {
+	channels, _, err := c.listChannels(ctx, options)
+	return channels, err
+}
+
+// listChannels is ListChannelsContext that also reports whether the list
+// came from the cache.
+func (c *Client) listChannels(ctx context.Context, options ListChannelsOptions) ([]Channel, bool, error) {
+	match, err := options.Filter.Matcher()
+	if err != nil {
+		return nil, false, err
+	}
+
+	// キャッシュには絞り込み前の一覧を保存する
+	unfiltered := options
+	unfiltered.Filter = ChannelFilter{}
+
+	var partial []Channel
+	channels, hit, err := cached(ctx, c, channelsCacheKey(options), func(ctx context.Context) ([]Channel, error) {
+		channels, err := Collect(c.Channels(ctx, unfiltered))
+		partial = channels
+		return channels, err
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			return nil, false, err
+		}
+		channels = partial
+	}
+
+	result := make([]Channel, 0, len(channels))
+	for _, ch := range channels {
+		if match(ch) {
+			result = append(result, ch)
+		}
+	}
+	if options.Sort != "" || options.Reverse {
+		SortChannels(result, options.Sort, options.Reverse)
+	}
+
+	return result, hit, err
+}
+
+// channelsCacheKey identifies a channel listing by the options that change
+// its result.
+func channelsCacheKey(options ListChannelsOptions) string {
+	types := []string{"public_channel"}
+	if len(options.Types) > 0 {
+		types = options.Types
+	}
+
+	key := "channels_" + strings.Join(types, "+")
+	if options.IncludeArchived {
+		key += "_archived"
+	}
+	if !options.AllChannels {
+		key += "_1000"
+	}
+	return key
+}
+
+// Channels streams channels from conversations.list, fetching pages as
+// they are consumed.
+func (c *Client) Channels(ctx context.Context, options ListChannelsOptions) iter.Seq2[Channel, error] {
+	maxChannels := 1000
+	if options.AllChannels {
+		maxChannels = 0 // 0は無制限を意味する
+	}
+
+	fetch := func(ctx context.Context, cursor Cursor) ([]Channel, Cursor, error) {
+		params := url.Values{}
+		if cursor.Token != "" {
+			params.Set("cursor", cursor.Token)
+		}
+		// 最大1000件ずつ取得
+		params.Set("limit", "1000")
+
+		// アーカイブされたチャンネルを除外（APIレベルでフィルタリング）
+		if !options.IncludeArchived {
+			params.Set("exclude_archived", "true")
+		}
+		if len(options.Types) > 0 {
+			params.Set("types", strings.Join(options.Types, ","))
+		}
+
+		var response struct {
+			Channels         []Channel        `json:"channels"`
+			ResponseMetadata ResponseMetadata `json:"response_metadata"`
+		}
+
+		if err := c.call(ctx, "GET", "conversations.list?"+params.Encode(), nil, &response); err != nil {
+			return nil, Cursor{}, fmt.Errorf("failed to list channels: %w", err)
+		}
+
+		return response.Channels, Cursor{Token: response.ResponseMetadata.NextCursor}, nil
+	}
+
+	pages := Paginate(ctx, fetch, PaginateOptions{
+		Limit:        maxChannels,
+		ProgressFunc: options.ProgressFunc,
+	})
+
+	if options.Filter.IsZero() {
+		return pages
+	}
+
+	return func(yield func(Channel, error) bool) {
+		match, err := options.Filter.Matcher()
+		if err != nil {
+			yield(Channel{}, err)
+			return
+		}
+
+		for ch, err := range pages {
+			if err != nil {
+				yield(ch, err)
+				return
+			}
+			if match(ch) && !yield(ch, nil) {
+				return
+			}
+		}
+	}
+}
+
+// GetConversationInfo returns a single conversation from conversations.info.
+func (c *Client) GetConversationInfo(ctx context.Context, channelID string) (*Channel, error) {
+	params := url.Values{}
+	params.Set("channel", channelID)
+	params.Set("include_num_members", "true")
+
+	var response struct {
+		Channel Channel `json:"channel"`
+	}
+
+	if err := c.call(ctx, "GET", "conversations.info?"+params.Encode(), nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get channel %s: %w", channelID, err)
+	}
+
+	return &response.Channel, nil
+}
+
+// OpenConversation opens (or returns the existing) direct message with one
+// user, or a group DM with several, using conversations.open.
+func (c *Client) OpenConversation(ctx context.Context, userIDs ...string) (*Channel, error) {
+	data := map[string]interface{}{
+		"users":     strings.Join(userIDs, ","),
+		"return_im": true,
+	}
+
+	var response struct {
+		Channel Channel `json:"channel"`
+	}
+
+	if err := c.call(ctx, "POST", "conversations.open", data, &response); err != nil {
+		return nil, fmt.Errorf("failed to open conversation: %w", err)
+	}
+
+	return &response.Channel, nil
+}
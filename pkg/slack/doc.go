@@ -0,0 +1,37 @@
+// Package slack is a client for the Slack Web API used by slakctl.
+//
+// Create a Client with a bot or user token and call its methods:
+//
+//	client := slack.NewClient(os.Getenv("SLACK_TOKEN"))
+//	if err := client.PostMessageContext(ctx, "C024BE91L", "Deploy finished"); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Every method has a context-taking variant; cancelling the context stops
+// in-flight requests, rate limiter waits and pagination. Methods that page
+// through results, such as Channels and SearchMessages, return an
+// iter.Seq2 so that items can be processed as they arrive:
+//
+//	for channel, err := range client.Channels(ctx, slack.ListChannelsOptions{AllChannels: true}) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(channel.Name)
+//	}
+//
+// Requests are paced per method according to Slack's rate limit tiers and
+// retried on HTTP 429 and transient failures. Failures reported by Slack
+// are returned as *APIError, which can be inspected with errors.As.
+//
+// Methods take conversation IDs. Use a Resolver to turn "#name", "name"
+// or "@user" into an ID:
+//
+//	channelID, err := slack.NewResolver(client).ResolveChannelID(ctx, "#deploys")
+//
+// # Compatibility
+//
+// This package follows semantic versioning together with the
+// github.com/oppai/slakctl module: exported identifiers are not removed or
+// changed incompatibly within a major version. New fields may be added to
+// structs, so construct them with field names.
+package slack
@@ -0,0 +1,24 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListEmojiContext returns the workspace's custom emoji from emoji.list,
+// mapping each name to its image URL or to "alias:<name>". With a Cache
+// configured, the list is served from and stored in it.
+func (c *Client) ListEmojiContext(ctx context.Context) (map[string]string, error) {
+	emoji, _, err := cached(ctx, c, "emoji", func(ctx context.Context) (map[string]string, error) {
+		var response struct {
+			Emoji map[string]string `json:"emoji"`
+		}
+
+		if err := c.call(ctx, "GET", "emoji.list", nil, &response); err != nil {
+			return nil, fmt.Errorf("failed to list emoji: %w", err)
+		}
+
+		return response.Emoji, nil
+	})
+	return emoji, err
+}
@@ -0,0 +1,113 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned when Slack answers a request with "ok": false or a
+// non-200 HTTP status. Use errors.As to inspect it:
+//
+//	var apiErr *slack.APIError
+//	if errors.As(err, &apiErr) && apiErr.Code == "missing_scope" {
+//		fmt.Println("needs", apiErr.Needed)
+//	}
+type APIError struct {
+	// Method is the Web API method that failed, e.g. "chat.postMessage".
+	Method string
+	// Code is Slack's error code, e.g. "channel_not_found". It is empty for
+	// HTTP errors that carry no Slack error code.
+	Code string
+	// Needed and Provided list the OAuth scopes for "missing_scope" errors.
+	Needed   string
+	Provided string
+	// Warnings holds any warnings Slack attached to the response.
+	Warnings []string
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Method)
+	b.WriteString(": ")
+
+	if e.Code != "" {
+		b.WriteString(e.Code)
+	} else {
+		fmt.Fprintf(&b, "API request failed with status %d", e.StatusCode)
+	}
+
+	if e.Needed != "" {
+		fmt.Fprintf(&b, " (needed: %s", e.Needed)
+		if e.Provided != "" {
+			fmt.Fprintf(&b, ", provided: %s", e.Provided)
+		}
+		b.WriteString(")")
+	}
+
+	return b.String()
+}
+
+// IsRateLimited reports whether the request was rejected by Slack's rate
+// limiter even after retrying.
+func (e *APIError) IsRateLimited() bool {
+	return e.Code == "ratelimited" || e.StatusCode == http.StatusTooManyRequests
+}
+
+// ErrorCode returns the Slack error code carried by err, or "" if err is
+// not an *APIError.
+func ErrorCode(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
+
+// IsErrorCode reports whether err is an *APIError with one of codes.
+func IsErrorCode(err error, codes ...string) bool {
+	code := ErrorCode(err)
+	if code == "" {
+		return false
+	}
+	for _, c := range codes {
+		if code == c {
+			return true
+		}
+	}
+	return false
+}
+
+// apiResponse is the envelope shared by every Web API response.
+type apiResponse struct {
+	OK               bool             `json:"ok"`
+	Error            string           `json:"error,omitempty"`
+	Needed           string           `json:"needed,omitempty"`
+	Provided         string           `json:"provided,omitempty"`
+	Warning          string           `json:"warning,omitempty"`
+	ResponseMetadata ResponseMetadata `json:"response_metadata"`
+}
+
+func (r *apiResponse) err(method string, statusCode int) *APIError {
+	if r.OK {
+		return nil
+	}
+
+	var warnings []string
+	if r.Warning != "" {
+		warnings = strings.Split(r.Warning, ",")
+	}
+	warnings = append(warnings, r.ResponseMetadata.Warnings...)
+
+	return &APIError{
+		Method:     method,
+		Code:       r.Error,
+		Needed:     r.Needed,
+		Provided:   r.Provided,
+		Warnings:   warnings,
+		StatusCode: statusCode,
+	}
+}
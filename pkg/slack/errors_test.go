@@ -0,0 +1,103 @@
+package slack
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	t.Run("should expose missing scope details", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			response := map[string]interface{}{
+				"ok":       false,
+				"error":    "missing_scope",
+				"needed":   "chat:write",
+				"provided": "channels:read",
+				"warning":  "superfluous_charset",
+				"response_metadata": map[string]interface{}{
+					"warnings": []string{"missing_charset"},
+				},
+			}
+			json.NewEncoder(w).Encode(response)
+		}))
+		defer server.Close()
+
+		client := NewClient("test-token",
+			WithBaseURL(server.URL+"/api"),
+			WithTransport(server.Client().Transport),
+		)
+
+		err := client.PostMessage("general", "hello")
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("expected *APIError, got: %v", err)
+		}
+		if apiErr.Method != "chat.postMessage" {
+			t.Errorf("expected method 'chat.postMessage', got: %s", apiErr.Method)
+		}
+		if apiErr.Code != "missing_scope" {
+			t.Errorf("expected code 'missing_scope', got: %s", apiErr.Code)
+		}
+		if apiErr.Needed != "chat:write" || apiErr.Provided != "channels:read" {
+			t.Errorf("unexpected scopes: needed=%s provided=%s", apiErr.Needed, apiErr.Provided)
+		}
+		if len(apiErr.Warnings) != 2 {
+			t.Errorf("expected 2 warnings, got: %v", apiErr.Warnings)
+		}
+		if apiErr.StatusCode != http.StatusOK {
+			t.Errorf("expected status 200, got: %d", apiErr.StatusCode)
+		}
+
+		expected := "failed to post message: chat.postMessage: missing_scope (needed: chat:write, provided: channels:read)"
+		if err.Error() != expected {
+			t.Errorf("expected error '%s', got: %s", expected, err.Error())
+		}
+	})
+
+	t.Run("should report HTTP errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		client := NewClient("test-token",
+			WithBaseURL(server.URL+"/api"),
+			WithTransport(server.Client().Transport),
+		)
+
+		err := client.TestAuth()
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("expected *APIError, got: %v", err)
+		}
+		if apiErr.StatusCode != http.StatusForbidden {
+			t.Errorf("expected status 403, got: %d", apiErr.StatusCode)
+		}
+		if apiErr.Code != "" {
+			t.Errorf("expected empty code, got: %s", apiErr.Code)
+		}
+	})
+}
+
+func TestIsErrorCode(t *testing.T) {
+	err := &APIError{Method: "conversations.info", Code: "channel_not_found"}
+	wrapped := errors.Join(errors.New("context"), err)
+
+	if !IsErrorCode(wrapped, "not_in_channel", "channel_not_found") {
+		t.Error("expected wrapped error to match channel_not_found")
+	}
+	if IsErrorCode(wrapped, "missing_scope") {
+		t.Error("expected wrapped error not to match missing_scope")
+	}
+	if IsErrorCode(errors.New("plain"), "channel_not_found") {
+		t.Error("expected plain error not to match")
+	}
+	if !(&APIError{StatusCode: http.StatusTooManyRequests}).IsRateLimited() {
+		t.Error("expected 429 to be rate limited")
+	}
+}
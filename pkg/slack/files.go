@@ -0,0 +1,73 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DownloadFile writes the contents of a private file URL, such as
+// File.URLPrivateDownload, to w. The token needs the files:read scope. It
+// is only sent to Slack hosts over https; external and remote files, which
+// may point anywhere, are downloaded without credentials.
+func (c *Client) DownloadFile(ctx context.Context, fileURL string, w io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", fileURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if c.isSlackHost(req.URL) {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download file: status %d", resp.StatusCode)
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to download file: %w", err)
+	}
+
+	return nil
+}
+
+// isSlackHost reports whether u points at slack.com or the configured API
+// host, or one of their subdomains such as files.slack.com, over https.
+// Plain http is only trusted for the configured API host itself, as used
+// by local test servers.
+func (c *Client) isSlackHost(u *url.URL) bool {
+	api, err := url.Parse(c.baseURL)
+	if err != nil {
+		api = &url.URL{}
+	}
+	if u.Scheme == "http" && api.Scheme == "http" && api.Host != "" && strings.EqualFold(u.Host, api.Host) {
+		return true
+	}
+	if u.Scheme != "https" {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	trusted := []string{"slack.com"}
+	if api.Scheme == "https" && api.Hostname() != "" {
+		trusted = append(trusted, strings.ToLower(api.Hostname()))
+	}
+	for _, t := range trusted {
+		if host == t || strings.HasSuffix(host, "."+t) {
+			return true
+		}
+	}
+	return false
+}
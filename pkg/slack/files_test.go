@@ -0,0 +1,77 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestDownloadFile(t *testing.T) {
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		w.Write([]byte("contents"))
+	}))
+	defer server.Close()
+
+	t.Run("should authenticate to the API host", func(t *testing.T) {
+		client := NewClient("test-token", WithBaseURL(server.URL+"/api"), WithRateLimiter(nil))
+
+		var buf bytes.Buffer
+		if err := client.DownloadFile(context.Background(), server.URL+"/files-pri/T1-F1/report.pdf", &buf); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if buf.String() != "contents" || auth != "Bearer test-token" {
+			t.Errorf("unexpected download: %q (authorization %q)", buf.String(), auth)
+		}
+	})
+
+	t.Run("should not send the token to other hosts", func(t *testing.T) {
+		client := NewClient("test-token", WithRateLimiter(nil))
+
+		var buf bytes.Buffer
+		if err := client.DownloadFile(context.Background(), server.URL+"/external.pdf", &buf); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if auth != "" {
+			t.Errorf("expected no authorization header, got: %q", auth)
+		}
+	})
+
+	t.Run("should only trust plain http for the API host itself", func(t *testing.T) {
+		client := NewClient("test-token", WithBaseURL("http://localhost:8080/api"))
+		tests := map[string]bool{
+			"http://localhost:8080/files-pri/T1-F1/a.png": true,
+			"http://localhost:9090/files-pri/T1-F1/a.png": false,
+			"http://files.slack.com/a.png":                false,
+		}
+		for raw, want := range tests {
+			u, _ := url.Parse(raw)
+			if got := client.isSlackHost(u); got != want {
+				t.Errorf("isSlackHost(%s) = %v, expected %v", raw, got, want)
+			}
+		}
+	})
+
+	t.Run("should recognize Slack hosts", func(t *testing.T) {
+		client := NewClient("test-token")
+		tests := map[string]bool{
+			"https://files.slack.com/files-pri/T1-F1/a.png": true,
+			"https://slack.com/files/a.png":                 true,
+			"https://evilslack.com/a.png":                   false,
+			"https://files.slack.com.example.org/a.png":     false,
+			"https://docs.google.com/document/d/1":          false,
+			"http://files.slack.com/files-pri/T1-F1/a.png":  false,
+			"ftp://files.slack.com/a.png":                   false,
+		}
+		for raw, want := range tests {
+			u, _ := url.Parse(raw)
+			if got := client.isSlackHost(u); got != want {
+				t.Errorf("isSlackHost(%s) = %v, expected %v", raw, got, want)
+			}
+		}
+	})
+}
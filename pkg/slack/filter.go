@@ -0,0 +1,121 @@
+package slack
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ChannelFilter selects channels by their attributes. The zero value
+// matches every channel.
+type ChannelFilter struct {
+	// Match is a glob such as "inc-*" matched against the whole channel
+	// name, or a regular expression between slashes such as "/^inc-\d+$/".
+	Match string
+	// MinMembers excludes channels with fewer members.
+	MinMembers int
+	// CreatedAfter excludes channels created at or before this time.
+	CreatedAfter time.Time
+	// MemberOnly keeps only channels the authenticated user is a member of.
+	MemberOnly bool
+	// SharedOnly keeps only channels shared with other workspaces.
+	SharedOnly bool
+}
+
+// IsZero reports whether f matches every channel.
+func (f ChannelFilter) IsZero() bool {
+	return f == ChannelFilter{}
+}
+
+// Matcher compiles f into a predicate. It fails if Match is not a valid
+// glob or regular expression.
+func (f ChannelFilter) Matcher() (func(Channel) bool, error) {
+	var matchName func(string) bool
+
+	switch {
+	case f.Match == "":
+	case len(f.Match) >= 2 && strings.HasPrefix(f.Match, "/") && strings.HasSuffix(f.Match, "/"):
+		re, err := regexp.Compile(f.Match[1 : len(f.Match)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel name pattern %s: %w", f.Match, err)
+		}
+		matchName = re.MatchString
+	default:
+		pattern := strings.TrimPrefix(f.Match, "#")
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid channel name pattern %s: %w", f.Match, err)
+		}
+		matchName = func(name string) bool {
+			ok, _ := path.Match(pattern, name)
+			return ok
+		}
+	}
+
+	return func(ch Channel) bool {
+		if matchName != nil && !matchName(ch.Name) {
+			return false
+		}
+		if ch.NumMembers < f.MinMembers {
+			return false
+		}
+		if !f.CreatedAfter.IsZero() && !ch.CreatedTime().After(f.CreatedAfter) {
+			return false
+		}
+		if f.MemberOnly && !ch.IsMember {
+			return false
+		}
+		if f.SharedOnly && !ch.IsShared && !ch.IsExtShared {
+			return false
+		}
+		return true
+	}, nil
+}
+
+// ChannelSort is the order of ListChannelsContext results.
+type ChannelSort string
+
+const (
+	// SortByName orders channels by name, A to Z.
+	SortByName ChannelSort = "name"
+	// SortByMembers orders channels by member count, largest first.
+	SortByMembers ChannelSort = "members"
+	// SortByCreated orders channels by creation time, newest first.
+	SortByCreated ChannelSort = "created"
+)
+
+// ParseChannelSort validates a sort key given by a user.
+func ParseChannelSort(s string) (ChannelSort, error) {
+	switch key := ChannelSort(s); key {
+	case "", SortByName, SortByMembers, SortByCreated:
+		return key, nil
+	}
+	return "", fmt.Errorf("invalid sort key %q: use name, members, or created", s)
+}
+
+// SortChannels sorts channels in place by key. Channels that compare equal
+// are ordered by name. reverse flips the order.
+func SortChannels(channels []Channel, key ChannelSort, reverse bool) {
+	less := func(a, b Channel) bool {
+		switch key {
+		case SortByMembers:
+			if a.NumMembers != b.NumMembers {
+				return a.NumMembers > b.NumMembers
+			}
+		case SortByCreated:
+			if a.Created != b.Created {
+				return a.Created > b.Created
+			}
+		}
+		return a.Name < b.Name
+	}
+
+	sort.SliceStable(channels, func(i, j int) bool {
+		if reverse {
+			return less(channels[j], channels[i])
+		}
+		return less(channels[i], channels[j])
+	})
+}
@@ -0,0 +1,135 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var filterTestChannels = []Channel{
+	{ID: "C1", Name: "general", NumMembers: 120, Created: 1600000000, IsMember: true},
+	{ID: "C2", Name: "inc-101", NumMembers: 8, Created: 1700000000, IsMember: true, IsExtShared: true},
+	{ID: "C3", Name: "inc-102", NumMembers: 15, Created: 1710000000},
+	{ID: "C4", Name: "random", NumMembers: 40, Created: 1500000000, IsShared: true},
+}
+
+func channelIDs(channels []Channel) []string {
+	ids := make([]string, 0, len(channels))
+	for _, ch := range channels {
+		ids = append(ids, ch.ID)
+	}
+	return ids
+}
+
+func TestChannelFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   ChannelFilter
+		expected []string
+	}{
+		{"zero value", ChannelFilter{}, []string{"C1", "C2", "C3", "C4"}},
+		{"glob", ChannelFilter{Match: "inc-*"}, []string{"C2", "C3"}},
+		{"glob with #", ChannelFilter{Match: "#gen*"}, []string{"C1"}},
+		{"regexp", ChannelFilter{Match: `/^inc-\d+2$/`}, []string{"C3"}},
+		{"min members", ChannelFilter{MinMembers: 15}, []string{"C1", "C3", "C4"}},
+		{"created after", ChannelFilter{CreatedAfter: time.Unix(1700000000, 0)}, []string{"C3"}},
+		{"member of", ChannelFilter{MemberOnly: true}, []string{"C1", "C2"}},
+		{"shared", ChannelFilter{SharedOnly: true}, []string{"C2", "C4"}},
+		{"combined", ChannelFilter{Match: "inc-*", MemberOnly: true}, []string{"C2"}},
+	}
+
+	for _, test := range tests {
+		match, err := test.filter.Matcher()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		var got []Channel
+		for _, ch := range filterTestChannels {
+			if match(ch) {
+				got = append(got, ch)
+			}
+		}
+		if ids := channelIDs(got); !reflect.DeepEqual(ids, test.expected) {
+			t.Errorf("%s: got %v, expected %v", test.name, ids, test.expected)
+		}
+	}
+
+	for _, pattern := range []string{"inc-[", "/inc-(/"} {
+		if _, err := (ChannelFilter{Match: pattern}).Matcher(); err == nil {
+			t.Errorf("expected error for pattern %s", pattern)
+		}
+	}
+}
+
+func TestSortChannels(t *testing.T) {
+	tests := []struct {
+		key      ChannelSort
+		reverse  bool
+		expected []string
+	}{
+		{SortByName, false, []string{"C1", "C2", "C3", "C4"}},
+		{SortByName, true, []string{"C4", "C3", "C2", "C1"}},
+		{SortByMembers, false, []string{"C1", "C4", "C3", "C2"}},
+		{SortByCreated, false, []string{"C3", "C2", "C1", "C4"}},
+		{SortByCreated, true, []string{"C4", "C1", "C2", "C3"}},
+	}
+
+	for _, test := range tests {
+		channels := append([]Channel(nil), filterTestChannels...)
+		SortChannels(channels, test.key, test.reverse)
+		if ids := channelIDs(channels); !reflect.DeepEqual(ids, test.expected) {
+			t.Errorf("SortChannels(%s, %v) = %v, expected %v", test.key, test.reverse, ids, test.expected)
+		}
+	}
+
+	if _, err := ParseChannelSort("size"); err == nil {
+		t.Error("expected error for unknown sort key")
+	}
+}
+
+func TestListChannelsFilterAndSort(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "channels": filterTestChannels})
+	}))
+	defer server.Close()
+
+	cache := memoryCache{}
+	client := NewClient("test-token", WithBaseURL(server.URL), WithRateLimiter(nil), WithCache(cache))
+
+	channels, err := client.ListChannelsContext(context.Background(), ListChannelsOptions{
+		Filter: ChannelFilter{MinMembers: 10},
+		Sort:   SortByMembers,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if ids := channelIDs(channels); !reflect.DeepEqual(ids, []string{"C1", "C4", "C3"}) {
+		t.Errorf("unexpected channels: %v", ids)
+	}
+
+	t.Run("should cache the unfiltered list", func(t *testing.T) {
+		var cached []Channel
+		if ok, _ := cache.Get("channels_public_channel_1000", &cached); !ok || len(cached) != 4 {
+			t.Errorf("expected all 4 channels in cache, got: %d", len(cached))
+		}
+	})
+
+	t.Run("should filter streamed channels", func(t *testing.T) {
+		var ids []string
+		for ch, err := range client.Channels(context.Background(), ListChannelsOptions{Filter: ChannelFilter{Match: "inc-*"}}) {
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			ids = append(ids, ch.ID)
+		}
+		if !reflect.DeepEqual(ids, []string{"C2", "C3"}) {
+			t.Errorf("unexpected channels: %v", ids)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// HistoryOptions configures History and Replies.
+type HistoryOptions struct {
+	// Oldest and Latest bound the time window. Zero values leave the window
+	// open on that side.
+	Oldest time.Time
+	Latest time.Time
+	// Inclusive includes messages with exactly the Oldest or Latest
+	// timestamp.
+	Inclusive bool
+	// Limit caps the number of messages returned. 0 means no limit.
+	Limit int
+	// ProgressFunc is called after every page with the number of messages
+	// fetched so far and Limit.
+	ProgressFunc func(current, total int)
+}
+
+func (o HistoryOptions) params() url.Values {
+	params := url.Values{}
+	if !o.Oldest.IsZero() {
+		params.Set("oldest", Timestamp(o.Oldest))
+	}
+	if !o.Latest.IsZero() {
+		params.Set("latest", Timestamp(o.Latest))
+	}
+	if o.Inclusive {
+		params.Set("inclusive", "true")
+	}
+	pageSize := historyPageSize
+	if o.Limit > 0 && o.Limit < pageSize {
+		// 数件だけ欲しい場合に 200 件取得しないようにする
+		pageSize = o.Limit
+	}
+	params.Set("limit", strconv.Itoa(pageSize))
+	return params
+}
+
+// conversations.history / replies の推奨ページサイズ
+const historyPageSize = 200
+
+// History streams messages of a conversation from conversations.history,
+// newest first.
+func (c *Client) History(ctx context.Context, channelID string, options HistoryOptions) iter.Seq2[Message, error] {
+	fetch := func(ctx context.Context, cursor Cursor) ([]Message, Cursor, error) {
+		params := options.params()
+		params.Set("channel", channelID)
+		if cursor.Token != "" {
+			params.Set("cursor", cursor.Token)
+		}
+
+		var response struct {
+			Messages         []Message        `json:"messages"`
+			HasMore          bool             `json:"has_more"`
+			ResponseMetadata ResponseMetadata `json:"response_metadata"`
+		}
+
+		if err := c.call(ctx, "GET", "conversations.history?"+params.Encode(), nil, &response); err != nil {
+			return nil, Cursor{}, fmt.Errorf("failed to fetch history: %w", err)
+		}
+
+		for i := range response.Messages {
+			response.Messages[i].Channel.ID = channelID
+		}
+
+		var next Cursor
+		if response.HasMore {
+			next.Token = response.ResponseMetadata.NextCursor
+		}
+		return response.Messages, next, nil
+	}
+
+	return Paginate(ctx, fetch, PaginateOptions{
+		Limit:        options.Limit,
+		ProgressFunc: options.ProgressFunc,
+	})
+}
+
+// HistoryContext collects History into a slice. If ctx is cancelled, the
+// messages fetched so far are returned together with the context's error.
+func (c *Client) HistoryContext(ctx context.Context, channelID string, options HistoryOptions) ([]Message, error) {
+	messages, err := Collect(c.History(ctx, channelID, options))
+	if err != nil && ctx.Err() == nil {
+		return nil, err
+	}
+	return messages, err
+}
+
+// LatestMessage returns the most recent message of a conversation, using
+// conversations.history with limit=1. It returns nil if the conversation
+// has no messages.
+func (c *Client) LatestMessage(ctx context.Context, channelID string) (*Message, error) {
+	for message, err := range c.History(ctx, channelID, HistoryOptions{Limit: 1}) {
+		if err != nil {
+			return nil, err
+		}
+		return &message, nil
+	}
+	return nil, nil
+}
+
+// Replies streams the messages of the thread started by threadTS from
+// conversations.replies, oldest first. The parent message is yielded first.
+func (c *Client) Replies(ctx context.Context, channelID, threadTS string, options HistoryOptions) iter.Seq2[Message, error] {
+	fetch := func(ctx context.Context, cursor Cursor) ([]Message, Cursor, error) {
+		params := options.params()
+		params.Set("channel", channelID)
+		params.Set("ts", threadTS)
+		if cursor.Token != "" {
+			params.Set("cursor", cursor.Token)
+		}
+
+		var response struct {
+			Messages         []Message        `json:"messages"`
+			HasMore          bool             `json:"has_more"`
+			ResponseMetadata ResponseMetadata `json:"response_metadata"`
+		}
+
+		if err := c.call(ctx, "GET", "conversations.replies?"+params.Encode(), nil, &response); err != nil {
+			return nil, Cursor{}, fmt.Errorf("failed to fetch replies: %w", err)
+		}
+
+		for i := range response.Messages {
+			response.Messages[i].Channel.ID = channelID
+		}
+
+		var next Cursor
+		if response.HasMore {
+			next.Token = response.ResponseMetadata.NextCursor
+		}
+		return response.Messages, next, nil
+	}
+
+	return Paginate(ctx, fetch, PaginateOptions{
+		Limit:        options.Limit,
+		ProgressFunc: options.ProgressFunc,
+	})
+}
+
+// RepliesContext collects the replies of a thread, excluding the parent
+// message.
+func (c *Client) RepliesContext(ctx context.Context, channelID, threadTS string) ([]Message, error) {
+	var replies []Message
+	for msg, err := range c.Replies(ctx, channelID, threadTS, HistoryOptions{}) {
+		if err != nil {
+			return replies, err
+		}
+		if msg.TS == threadTS {
+			continue
+		}
+		replies = append(replies, msg)
+	}
+	return replies, nil
+}
@@ -0,0 +1,163 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/conversations.history" {
+			t.Errorf("expected path '/api/conversations.history', got: %s", r.URL.Path)
+		}
+
+		query := r.URL.Query()
+		if query.Get("channel") != "C1234567890" {
+			t.Errorf("expected channel 'C1234567890', got: %s", query.Get("channel"))
+		}
+		if query.Get("oldest") != "1700000000.000000" {
+			t.Errorf("expected oldest '1700000000.000000', got: %s", query.Get("oldest"))
+		}
+
+		response := map[string]interface{}{"ok": true}
+		if query.Get("cursor") == "" {
+			response["messages"] = []map[string]interface{}{
+				{"type": "message", "text": "second", "ts": "1700000002.000000", "thread_ts": "1700000002.000000", "reply_count": 2},
+			}
+			response["has_more"] = true
+			response["response_metadata"] = map[string]string{"next_cursor": "page2"}
+		} else {
+			response["messages"] = []map[string]interface{}{
+				{"type": "message", "text": "first", "ts": "1700000001.000000"},
+			}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token",
+		WithBaseURL(server.URL+"/api"),
+		WithTransport(server.Client().Transport),
+		WithRateLimiter(nil),
+	)
+
+	messages, err := client.HistoryContext(context.Background(), "C1234567890", HistoryOptions{
+		Oldest: time.Unix(1700000000, 0),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got: %d", len(messages))
+	}
+	if messages[0].Channel.ID != "C1234567890" {
+		t.Errorf("expected channel ID to be set, got: %s", messages[0].Channel.ID)
+	}
+	if !messages[0].IsThreadParent() {
+		t.Error("expected first message to be a thread parent")
+	}
+	if messages[1].IsThreadParent() {
+		t.Error("expected second message not to be a thread parent")
+	}
+}
+
+func TestRepliesContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/conversations.replies" {
+			t.Errorf("expected path '/api/conversations.replies', got: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("ts") != "1700000002.000000" {
+			t.Errorf("expected ts '1700000002.000000', got: %s", r.URL.Query().Get("ts"))
+		}
+
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"text": "parent", "ts": "1700000002.000000", "thread_ts": "1700000002.000000"},
+				{"text": "reply", "ts": "1700000003.000000", "thread_ts": "1700000002.000000"},
+			},
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token",
+		WithBaseURL(server.URL+"/api"),
+		WithTransport(server.Client().Transport),
+	)
+
+	replies, err := client.RepliesContext(context.Background(), "C1234567890", "1700000002.000000")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(replies) != 1 || replies[0].Text != "reply" {
+		t.Errorf("expected only the reply, got: %+v", replies)
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		ts       string
+		expected time.Time
+	}{
+		{"1712345678.123456", time.Unix(1712345678, 123456000)},
+		{"1712345678", time.Unix(1712345678, 0)},
+		{"1712345678.1", time.Unix(1712345678, 100000000)},
+	}
+
+	for _, test := range tests {
+		got, err := ParseTimestamp(test.ts)
+		if err != nil {
+			t.Errorf("ParseTimestamp(%s) returned error: %v", test.ts, err)
+			continue
+		}
+		if !got.Equal(test.expected) {
+			t.Errorf("ParseTimestamp(%s) = %v, expected %v", test.ts, got, test.expected)
+		}
+	}
+
+	if _, err := ParseTimestamp("not-a-ts"); err == nil {
+		t.Error("expected error for invalid timestamp")
+	}
+
+	if ts := Timestamp(time.Unix(1712345678, 123456000)); ts != "1712345678.123456" {
+		t.Errorf("expected '1712345678.123456', got: %s", ts)
+	}
+}
+
+func TestLatestMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if limit := r.URL.Query().Get("limit"); limit != "1" {
+			t.Errorf("expected limit '1', got: %s", limit)
+		}
+
+		response := map[string]interface{}{"ok": true, "has_more": true}
+		if r.URL.Query().Get("channel") == "CLATEST1" {
+			response["messages"] = []map[string]interface{}{
+				{"type": "message", "user": "U1", "text": "latest", "ts": "1700000002.000000"},
+			}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithRateLimiter(nil))
+
+	message, err := client.LatestMessage(context.Background(), "CLATEST1")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if message == nil || message.Text != "latest" || message.Channel.ID != "CLATEST1" {
+		t.Errorf("unexpected message: %+v", message)
+	}
+
+	message, err = client.LatestMessage(context.Background(), "CEMPTY01")
+	if err != nil || message != nil {
+		t.Errorf("expected no message for an empty channel, got: %+v (%v)", message, err)
+	}
+}
@@ -0,0 +1,86 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+var channelNamePattern = regexp.MustCompile(`^[a-z0-9_-]{1,80}$`)
+
+// ValidateChannelName checks name against Slack's rules for channel names:
+// at most 80 lowercase letters, numbers, hyphens and underscores.
+func ValidateChannelName(name string) error {
+	if !channelNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid channel name %q: use up to 80 lowercase letters, numbers, hyphens and underscores", name)
+	}
+	return nil
+}
+
+// CreateChannel creates a public channel, or a private one if private is
+// set, with conversations.create.
+func (c *Client) CreateChannel(ctx context.Context, name string, private bool) (*Channel, error) {
+	data := map[string]interface{}{
+		"name":       name,
+		"is_private": private,
+	}
+
+	var response struct {
+		Channel Channel `json:"channel"`
+	}
+
+	if err := c.call(ctx, "POST", "conversations.create", data, &response); err != nil {
+		return nil, fmt.Errorf("failed to create channel %s: %w", name, err)
+	}
+
+	c.invalidateChannels()
+	return &response.Channel, nil
+}
+
+// ArchiveChannel archives a channel with conversations.archive.
+func (c *Client) ArchiveChannel(ctx context.Context, channelID string) error {
+	data := map[string]interface{}{
+		"channel": channelID,
+	}
+
+	if err := c.call(ctx, "POST", "conversations.archive", data, nil); err != nil {
+		return fmt.Errorf("failed to archive channel %s: %w", channelID, err)
+	}
+
+	c.invalidateChannels()
+	return nil
+}
+
+// UnarchiveChannel reverses ArchiveChannel with conversations.unarchive.
+func (c *Client) UnarchiveChannel(ctx context.Context, channelID string) error {
+	data := map[string]interface{}{
+		"channel": channelID,
+	}
+
+	if err := c.call(ctx, "POST", "conversations.unarchive", data, nil); err != nil {
+		return fmt.Errorf("failed to unarchive channel %s: %w", channelID, err)
+	}
+
+	c.invalidateChannels()
+	return nil
+}
+
+// RenameChannel renames a channel with conversations.rename and returns
+// it with its new name.
+func (c *Client) RenameChannel(ctx context.Context, channelID, name string) (*Channel, error) {
+	data := map[string]interface{}{
+		"channel": channelID,
+		"name":    name,
+	}
+
+	var response struct {
+		Channel Channel `json:"channel"`
+	}
+
+	if err := c.call(ctx, "POST", "conversations.rename", data, &response); err != nil {
+		return nil, fmt.Errorf("failed to rename channel %s: %w", channelID, err)
+	}
+
+	c.invalidateChannels()
+	return &response.Channel, nil
+}
@@ -0,0 +1,94 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChannelLifecycle(t *testing.T) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST, got: %s", r.Method)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var data map[string]interface{}
+		json.Unmarshal(body, &data)
+		data["method"] = r.URL.Path
+		requests = append(requests, data)
+
+		response := map[string]interface{}{"ok": true}
+		switch r.URL.Path {
+		case "/conversations.create":
+			response["channel"] = map[string]interface{}{"id": "C1", "name": data["name"], "is_private": data["is_private"]}
+		case "/conversations.rename":
+			response["channel"] = map[string]interface{}{"id": data["channel"], "name": data["name"]}
+		case "/conversations.archive":
+			response = map[string]interface{}{"ok": false, "error": "already_archived"}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	cache := memoryCache{"channels_public_channel": []byte("[]"), "users": []byte("[]")}
+	client := NewClient("test-token", WithBaseURL(server.URL), WithRateLimiter(nil), WithCache(cache))
+	ctx := context.Background()
+
+	channel, err := client.CreateChannel(ctx, "inc-101", true)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if channel.ID != "C1" || !channel.IsPrivate || requests[0]["is_private"] != true {
+		t.Errorf("unexpected channel: %+v (request %v)", channel, requests[0])
+	}
+
+	t.Run("should invalidate cached channel lists", func(t *testing.T) {
+		if _, ok := cache["channels_public_channel"]; ok {
+			t.Error("expected channel list to be dropped from cache")
+		}
+		if _, ok := cache["users"]; !ok {
+			t.Error("expected users to stay cached")
+		}
+	})
+
+	t.Run("should rename channels", func(t *testing.T) {
+		renamed, err := client.RenameChannel(ctx, "C1", "inc-101-db")
+		if err != nil || renamed.Name != "inc-101-db" {
+			t.Errorf("unexpected result: %+v (%v)", renamed, err)
+		}
+	})
+
+	t.Run("should unarchive channels", func(t *testing.T) {
+		if err := client.UnarchiveChannel(ctx, "C1"); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+		if last := requests[len(requests)-1]; last["method"] != "/conversations.unarchive" || last["channel"] != "C1" {
+			t.Errorf("unexpected request: %v", last)
+		}
+	})
+
+	t.Run("should return API errors", func(t *testing.T) {
+		err := client.ArchiveChannel(ctx, "C1")
+		if !IsErrorCode(err, "already_archived") {
+			t.Errorf("expected already_archived, got: %v", err)
+		}
+	})
+}
+
+func TestValidateChannelName(t *testing.T) {
+	for _, name := range []string{"general", "inc-101", "team_web", "a"} {
+		if err := ValidateChannelName(name); err != nil {
+			t.Errorf("expected %s to be valid, got: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "General", "has space", "dot.name", string(make([]byte, 81))} {
+		if err := ValidateChannelName(name); err == nil {
+			t.Errorf("expected %q to be invalid", name)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strings"
+)
+
+// SetTopic sets the topic of a channel with conversations.setTopic. An
+// empty topic clears it.
+func (c *Client) SetTopic(ctx context.Context, channelID, topic string) error {
+	data := map[string]interface{}{
+		"channel": channelID,
+		"topic":   topic,
+	}
+
+	if err := c.call(ctx, "POST", "conversations.setTopic", data, nil); err != nil {
+		return fmt.Errorf("failed to set topic of %s: %w", channelID, err)
+	}
+
+	c.invalidateChannels()
+	return nil
+}
+
+// SetPurpose sets the purpose (description) of a channel with
+// conversations.setPurpose. An empty purpose clears it.
+func (c *Client) SetPurpose(ctx context.Context, channelID, purpose string) error {
+	data := map[string]interface{}{
+		"channel": channelID,
+		"purpose": purpose,
+	}
+
+	if err := c.call(ctx, "POST", "conversations.setPurpose", data, nil); err != nil {
+		return fmt.Errorf("failed to set purpose of %s: %w", channelID, err)
+	}
+
+	c.invalidateChannels()
+	return nil
+}
+
+// JoinChannel adds the authenticated user to a public channel with
+// conversations.join. Joining a channel twice is not an error.
+func (c *Client) JoinChannel(ctx context.Context, channelID string) (*Channel, error) {
+	data := map[string]interface{}{
+		"channel": channelID,
+	}
+
+	var response struct {
+		Channel Channel `json:"channel"`
+	}
+
+	if err := c.call(ctx, "POST", "conversations.join", data, &response); err != nil {
+		return nil, fmt.Errorf("failed to join channel %s: %w", channelID, err)
+	}
+
+	c.invalidateChannels()
+	return &response.Channel, nil
+}
+
+// LeaveChannel removes the authenticated user from a channel with
+// conversations.leave. It reports false if the user was not a member.
+func (c *Client) LeaveChannel(ctx context.Context, channelID string) (bool, error) {
+	data := map[string]interface{}{
+		"channel": channelID,
+	}
+
+	var response struct {
+		NotInChannel bool `json:"not_in_channel"`
+	}
+
+	if err := c.call(ctx, "POST", "conversations.leave", data, &response); err != nil {
+		return false, fmt.Errorf("failed to leave channel %s: %w", channelID, err)
+	}
+
+	c.invalidateChannels()
+	return !response.NotInChannel, nil
+}
+
+// InviteToChannel adds users to a channel with conversations.invite. Slack
+// fails the whole request if any of the users cannot be invited, for
+// example with already_in_channel.
+func (c *Client) InviteToChannel(ctx context.Context, channelID string, userIDs ...string) error {
+	data := map[string]interface{}{
+		"channel": channelID,
+		"users":   strings.Join(userIDs, ","),
+	}
+
+	if err := c.call(ctx, "POST", "conversations.invite", data, nil); err != nil {
+		return fmt.Errorf("failed to invite users to %s: %w", channelID, err)
+	}
+
+	c.invalidateChannels()
+	return nil
+}
+
+// KickFromChannel removes a user from a channel with conversations.kick.
+func (c *Client) KickFromChannel(ctx context.Context, channelID, userID string) error {
+	data := map[string]interface{}{
+		"channel": channelID,
+		"user":    userID,
+	}
+
+	if err := c.call(ctx, "POST", "conversations.kick", data, nil); err != nil {
+		return fmt.Errorf("failed to remove %s from %s: %w", userID, channelID, err)
+	}
+
+	c.invalidateChannels()
+	return nil
+}
+
+// ListChannelMembers returns the IDs of every member of a channel.
+func (c *Client) ListChannelMembers(ctx context.Context, channelID string) ([]string, error) {
+	return Collect(c.ChannelMembers(ctx, channelID))
+}
+
+// ChannelMembers streams the user IDs of a channel's members from
+// conversations.members.
+func (c *Client) ChannelMembers(ctx context.Context, channelID string) iter.Seq2[string, error] {
+	fetch := func(ctx context.Context, cursor Cursor) ([]string, Cursor, error) {
+		params := url.Values{}
+		params.Set("channel", channelID)
+		params.Set("limit", "200")
+		if cursor.Token != "" {
+			params.Set("cursor", cursor.Token)
+		}
+
+		var response struct {
+			Members          []string         `json:"members"`
+			ResponseMetadata ResponseMetadata `json:"response_metadata"`
+		}
+
+		if err := c.call(ctx, "GET", "conversations.members?"+params.Encode(), nil, &response); err != nil {
+			return nil, Cursor{}, fmt.Errorf("failed to list members of %s: %w", channelID, err)
+		}
+
+		return response.Members, Cursor{Token: response.ResponseMetadata.NextCursor}, nil
+	}
+
+	return Paginate(ctx, fetch, PaginateOptions{})
+}
@@ -0,0 +1,108 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestChannelMembership(t *testing.T) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data := map[string]interface{}{}
+		if r.Method == "POST" {
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &data)
+		}
+		data["method"] = r.URL.Path
+		requests = append(requests, data)
+
+		response := map[string]interface{}{"ok": true}
+		switch r.URL.Path {
+		case "/conversations.join":
+			response["channel"] = map[string]interface{}{"id": data["channel"], "name": "general", "is_member": true}
+		case "/conversations.leave":
+			response["not_in_channel"] = true
+		case "/conversations.kick":
+			response = map[string]interface{}{"ok": false, "error": "not_in_channel"}
+		case "/conversations.members":
+			if r.URL.Query().Get("cursor") == "" {
+				response["members"] = []string{"U1", "U2"}
+				response["response_metadata"] = map[string]interface{}{"next_cursor": "page2"}
+			} else {
+				response["members"] = []string{"U3"}
+			}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	cache := memoryCache{"channels_public_channel": []byte("[]")}
+	client := NewClient("test-token", WithBaseURL(server.URL), WithRateLimiter(nil), WithCache(cache))
+	ctx := context.Background()
+
+	t.Run("should set topic and purpose", func(t *testing.T) {
+		if err := client.SetTopic(ctx, "C1", "on-call: @alice"); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if err := client.SetPurpose(ctx, "C1", "Incident response"); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		topic, purpose := requests[len(requests)-2], requests[len(requests)-1]
+		if topic["method"] != "/conversations.setTopic" || topic["topic"] != "on-call: @alice" {
+			t.Errorf("unexpected request: %v", topic)
+		}
+		if purpose["method"] != "/conversations.setPurpose" || purpose["purpose"] != "Incident response" {
+			t.Errorf("unexpected request: %v", purpose)
+		}
+		if _, ok := cache["channels_public_channel"]; ok {
+			t.Error("expected channel list to be dropped from cache")
+		}
+	})
+
+	t.Run("should join and leave channels", func(t *testing.T) {
+		channel, err := client.JoinChannel(ctx, "C1")
+		if err != nil || !channel.IsMember {
+			t.Errorf("unexpected result: %+v (%v)", channel, err)
+		}
+
+		left, err := client.LeaveChannel(ctx, "C1")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if left {
+			t.Error("expected not_in_channel to be reported")
+		}
+	})
+
+	t.Run("should invite several users at once", func(t *testing.T) {
+		if err := client.InviteToChannel(ctx, "C1", "U1", "U2"); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if last := requests[len(requests)-1]; last["method"] != "/conversations.invite" || last["users"] != "U1,U2" {
+			t.Errorf("unexpected request: %v", last)
+		}
+	})
+
+	t.Run("should return API errors", func(t *testing.T) {
+		err := client.KickFromChannel(ctx, "C1", "U9")
+		if !IsErrorCode(err, "not_in_channel") {
+			t.Errorf("expected not_in_channel, got: %v", err)
+		}
+	})
+
+	t.Run("should list members across pages", func(t *testing.T) {
+		members, err := client.ListChannelMembers(ctx, "C1")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !reflect.DeepEqual(members, []string{"U1", "U2", "U3"}) {
+			t.Errorf("unexpected members: %v", members)
+		}
+	})
+}
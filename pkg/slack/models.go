@@ -0,0 +1,184 @@
+package slack
+
+import (
+	"slices"
+	"time"
+)
+
+// Channel is a conversation as returned by conversations.list and
+// conversations.info: a public or private channel, a direct message (IsIM)
+// or a group direct message (IsMpIM).
+type Channel struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	IsArchived  bool         `json:"is_archived"`
+	IsPrivate   bool         `json:"is_private"`
+	IsIM        bool         `json:"is_im"`
+	IsMpIM      bool         `json:"is_mpim"`
+	IsShared    bool         `json:"is_shared"`
+	IsExtShared bool         `json:"is_ext_shared"`
+	IsMember    bool         `json:"is_member"`
+	Topic       ChannelTopic `json:"topic"`
+	Purpose     ChannelTopic `json:"purpose"`
+	NumMembers  int          `json:"num_members"`
+	Creator     string       `json:"creator"`
+	// Created is the creation time as a Unix timestamp.
+	Created int64 `json:"created"`
+	// User is the other member of a direct message.
+	User string `json:"user,omitempty"`
+}
+
+// ChannelTopic is the topic or purpose of a channel.
+type ChannelTopic struct {
+	Value   string `json:"value"`
+	Creator string `json:"creator"`
+	LastSet int64  `json:"last_set"`
+}
+
+// CreatedTime returns Created as a time.Time.
+func (c Channel) CreatedTime() time.Time {
+	return time.Unix(c.Created, 0)
+}
+
+// Type returns the conversation type as used by the types parameter of
+// conversations.list: "public_channel", "private_channel", "im" or "mpim".
+func (c Channel) Type() string {
+	switch {
+	case c.IsIM:
+		return "im"
+	case c.IsMpIM:
+		return "mpim"
+	case c.IsPrivate:
+		return "private_channel"
+	}
+	return "public_channel"
+}
+
+// ChannelRef identifies the conversation a Message belongs to.
+type ChannelRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// IsIM is set for direct messages, whose Name is the other user.
+	IsIM bool `json:"is_im,omitempty"`
+}
+
+// Message is a single Slack message. Messages returned by search carry
+// Channel and Permalink; messages returned by history have Channel.ID set
+// to the conversation they were read from.
+type Message struct {
+	Type       string     `json:"type"`
+	Subtype    string     `json:"subtype,omitempty"`
+	Text       string     `json:"text"`
+	User       string     `json:"user"`
+	Username   string     `json:"username"`
+	BotID      string     `json:"bot_id,omitempty"`
+	Channel    ChannelRef `json:"channel"`
+	TS         string     `json:"ts"`
+	ThreadTS   string     `json:"thread_ts,omitempty"`
+	ReplyCount int        `json:"reply_count,omitempty"`
+	Files      []File     `json:"files,omitempty"`
+	Permalink  string     `json:"permalink"`
+}
+
+// IsThreadParent reports whether m starts a thread with replies.
+func (m Message) IsThreadParent() bool {
+	return m.ReplyCount > 0 && (m.ThreadTS == "" || m.ThreadTS == m.TS)
+}
+
+// File is a file shared in a message or found by SearchFiles. Uploader and
+// sharing fields are only set by search results.
+type File struct {
+	ID                 string   `json:"id"`
+	Name               string   `json:"name"`
+	Title              string   `json:"title"`
+	Mimetype           string   `json:"mimetype"`
+	Filetype           string   `json:"filetype"`
+	Size               int64    `json:"size"`
+	URLPrivate         string   `json:"url_private,omitempty"`
+	URLPrivateDownload string   `json:"url_private_download,omitempty"`
+	Permalink          string   `json:"permalink,omitempty"`
+	Created            int64    `json:"created,omitempty"`
+	User               string   `json:"user,omitempty"`
+	Username           string   `json:"username,omitempty"`
+	Channels           []string `json:"channels,omitempty"`
+	Groups             []string `json:"groups,omitempty"`
+	IMs                []string `json:"ims,omitempty"`
+}
+
+// CreatedTime returns Created as a time.Time.
+func (f File) CreatedTime() time.Time {
+	return time.Unix(f.Created, 0)
+}
+
+// SharedIn returns the IDs of the channels, private channels and direct
+// messages f is shared in.
+func (f File) SharedIn() []string {
+	return slices.Concat(f.Channels, f.Groups, f.IMs)
+}
+
+// User is a member of the workspace.
+type User struct {
+	ID        string      `json:"id"`
+	TeamID    string      `json:"team_id"`
+	Name      string      `json:"name"`
+	RealName  string      `json:"real_name"`
+	Deleted   bool        `json:"deleted"`
+	IsBot     bool        `json:"is_bot"`
+	IsAppUser bool        `json:"is_app_user,omitempty"`
+	Profile   UserProfile `json:"profile"`
+}
+
+// UserProfile holds the profile fields of a User.
+type UserProfile struct {
+	DisplayName string `json:"display_name"`
+	RealName    string `json:"real_name"`
+	Email       string `json:"email,omitempty"`
+	Image48     string `json:"image_48,omitempty"`
+}
+
+// DisplayName returns the name Slack shows for u: the display name if set,
+// then the real name, then the username.
+func (u User) DisplayName() string {
+	switch {
+	case u.Profile.DisplayName != "":
+		return u.Profile.DisplayName
+	case u.Profile.RealName != "":
+		return u.Profile.RealName
+	case u.RealName != "":
+		return u.RealName
+	}
+	return u.Name
+}
+
+// Paging describes the page-number pagination of search results.
+type Paging struct {
+	TotalCount int `json:"total_count"`
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	PageCount  int `json:"page_count"`
+	First      int `json:"first"`
+	Last       int `json:"last"`
+}
+
+// FileSearchResult holds the files matched by a search.
+type FileSearchResult struct {
+	Matches    []File `json:"matches"`
+	Total      int    `json:"total"`
+	Pagination Paging `json:"paging"`
+}
+
+// CombinedSearchResult holds the messages and files matched by
+// SearchMessagesAndFilesContext.
+type CombinedSearchResult struct {
+	Messages SearchResult     `json:"messages"`
+	Files    FileSearchResult `json:"files"`
+}
+
+// SearchResult holds the messages matched by a search.
+type SearchResult struct {
+	Matches []Message `json:"matches"`
+	Total   int       `json:"total"`
+	// Pagination is encoded as "paging", as in earlier versions of
+	// slakctl's JSON output; responses are read from Slack's "pagination".
+	Pagination Paging `json:"paging"`
+}
@@ -0,0 +1,96 @@
+package slack
+
+import (
+	"context"
+	"iter"
+)
+
+// Cursor identifies a page of results. Most list methods use an opaque
+// Token taken from response_metadata.next_cursor, while search methods use
+// 1-based Page numbers. The zero Cursor requests the first page.
+type Cursor struct {
+	Token string
+	Page  int
+}
+
+// IsZero reports whether c points at no page, which ends pagination when
+// returned as the next cursor.
+func (c Cursor) IsZero() bool {
+	return c.Token == "" && c.Page == 0
+}
+
+// PageFunc fetches the page at cursor and returns its items along with the
+// cursor of the following page, or the zero Cursor on the last page.
+type PageFunc[T any] func(ctx context.Context, cursor Cursor) (items []T, next Cursor, err error)
+
+// PaginateOptions configures Paginate.
+type PaginateOptions struct {
+	// Limit stops pagination after this many items. 0 means no limit.
+	Limit int
+	// ProgressFunc is called after every page with the number of items
+	// yielded so far and Limit.
+	ProgressFunc func(current, total int)
+}
+
+// Paginate streams the items of every page returned by fetch. Pages are
+// requested lazily as the caller consumes items, so breaking out of the
+// loop stops further requests. Requests made through the Client are paced
+// by its rate limiter. An error, including cancellation of ctx, is yielded
+// once with the zero item and ends the sequence.
+func Paginate[T any](ctx context.Context, fetch PageFunc[T], options PaginateOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		count := 0
+		cursor := Cursor{}
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items, next, err := fetch(ctx, cursor)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+				count++
+
+				if options.Limit > 0 && count >= options.Limit {
+					if options.ProgressFunc != nil {
+						options.ProgressFunc(count, options.Limit)
+					}
+					return
+				}
+			}
+
+			if options.ProgressFunc != nil {
+				options.ProgressFunc(count, options.Limit)
+			}
+
+			// 同じカーソルが返ってきた場合も無限ループを避けて終了する
+			if next.IsZero() || next == cursor {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
+// Collect gathers every item of seq into a slice. If seq yields an error,
+// the items collected so far are returned together with it.
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
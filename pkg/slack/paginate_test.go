@@ -0,0 +1,133 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// pagesOf は items を size 件ずつのページとして返す PageFunc を作る
+func pagesOf(items []int, size int, requests *int) PageFunc[int] {
+	return func(ctx context.Context, cursor Cursor) ([]int, Cursor, error) {
+		*requests++
+		page := cursor.Page
+		if page == 0 {
+			page = 1
+		}
+
+		start := (page - 1) * size
+		end := start + size
+		if end >= len(items) {
+			return items[start:], Cursor{}, nil
+		}
+		return items[start:end], Cursor{Page: page + 1}, nil
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+
+	t.Run("should yield every item across pages", func(t *testing.T) {
+		requests := 0
+		got, err := Collect(Paginate(context.Background(), pagesOf(items, 3, &requests), PaginateOptions{}))
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(got) != len(items) {
+			t.Errorf("expected %d items, got: %d", len(items), len(got))
+		}
+		if requests != 3 {
+			t.Errorf("expected 3 requests, got: %d", requests)
+		}
+	})
+
+	t.Run("should stop at limit and report progress", func(t *testing.T) {
+		requests := 0
+		var progress []int
+		got, err := Collect(Paginate(context.Background(), pagesOf(items, 3, &requests), PaginateOptions{
+			Limit:        4,
+			ProgressFunc: func(current, total int) { progress = append(progress, current) },
+		}))
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(got) != 4 {
+			t.Errorf("expected 4 items, got: %d", len(got))
+		}
+		if requests != 2 {
+			t.Errorf("expected 2 requests, got: %d", requests)
+		}
+		if fmt.Sprint(progress) != "[3 4]" {
+			t.Errorf("expected progress [3 4], got: %v", progress)
+		}
+	})
+
+	t.Run("should stop fetching when the consumer breaks", func(t *testing.T) {
+		requests := 0
+		for item, err := range Paginate(context.Background(), pagesOf(items, 3, &requests), PaginateOptions{}) {
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if item == 2 {
+				break
+			}
+		}
+		if requests != 1 {
+			t.Errorf("expected 1 request, got: %d", requests)
+		}
+	})
+
+	t.Run("should return partial items with error", func(t *testing.T) {
+		fetchErr := errors.New("boom")
+		fetch := func(ctx context.Context, cursor Cursor) ([]int, Cursor, error) {
+			if cursor.Token == "" {
+				return []int{1, 2}, Cursor{Token: "next"}, nil
+			}
+			return nil, Cursor{}, fetchErr
+		}
+
+		got, err := Collect(Paginate(context.Background(), fetch, PaginateOptions{}))
+		if !errors.Is(err, fetchErr) {
+			t.Fatalf("expected fetch error, got: %v", err)
+		}
+		if len(got) != 2 {
+			t.Errorf("expected 2 partial items, got: %d", len(got))
+		}
+	})
+}
+
+func TestChannelsIterator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{"ok": true}
+		if r.URL.Query().Get("cursor") == "" {
+			response["channels"] = []Channel{{ID: "C1", Name: "general"}}
+			response["response_metadata"] = map[string]string{"next_cursor": "page2"}
+		} else {
+			response["channels"] = []Channel{{ID: "C2", Name: "random"}}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token",
+		WithBaseURL(server.URL+"/api"),
+		WithTransport(server.Client().Transport),
+		WithRateLimiter(nil),
+	)
+
+	var names []string
+	for channel, err := range client.Channels(context.Background(), ListChannelsOptions{AllChannels: true}) {
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		names = append(names, channel.Name)
+	}
+
+	if fmt.Sprint(names) != "[general random]" {
+		t.Errorf("expected [general random], got: %v", names)
+	}
+}
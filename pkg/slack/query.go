@@ -0,0 +1,164 @@
+package slack
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SearchQuery builds a search.messages query from structured parts instead
+// of Slack's modifier syntax. The zero value matches nothing; set Terms or
+// at least one modifier.
+type SearchQuery struct {
+	// Terms are the words to search for.
+	Terms []string
+	// Exact searches for Terms as one quoted phrase.
+	Exact bool
+
+	// In limits results to conversations: channel names (with or without
+	// "#"), conversation IDs or user IDs for direct messages.
+	In []string
+	// From and To limit results to messages from, or direct messages to,
+	// users given by user ID or user name.
+	From []string
+	To   []string
+
+	// Before and After are exclusive date bounds; On matches one day.
+	// Only the date in the time's location is used.
+	Before time.Time
+	After  time.Time
+	On     time.Time
+	// During matches a period: a year ("2024"), a month ("2024-05" or
+	// "may"), or "today", "yesterday", "week", "month" or "year".
+	During string
+
+	// Has and Is filter by what messages contain and are; see SearchHas
+	// and SearchIs.
+	Has []string
+	Is  []string
+}
+
+// SearchHas and SearchIs list the values accepted by SearchQuery.Has and
+// SearchQuery.Is.
+var (
+	SearchHas = []string{"link", "reaction", "pin", "file"}
+	SearchIs  = []string{"thread", "saved"}
+)
+
+var (
+	duringYearMonth = regexp.MustCompile(`^\d{4}(-(0[1-9]|1[0-2]))?$`)
+	duringWords     = []string{
+		"today", "yesterday", "week", "month", "year",
+		"january", "february", "march", "april", "may", "june",
+		"july", "august", "september", "october", "november", "december",
+	}
+)
+
+// Validate reports the first problem that would make the query invalid or
+// contradictory.
+func (q SearchQuery) Validate() error {
+	if q.String() == "" {
+		return fmt.Errorf("search query is empty: give search terms or at least one filter")
+	}
+
+	for _, term := range q.Terms {
+		if strings.TrimSpace(term) == "" {
+			return fmt.Errorf("search terms must not be empty")
+		}
+		if q.Exact && strings.Contains(term, `"`) {
+			return fmt.Errorf("exact search terms must not contain double quotes")
+		}
+	}
+	if q.Exact && len(q.Terms) == 0 {
+		return fmt.Errorf("exact search needs search terms")
+	}
+
+	for _, v := range q.Has {
+		if !slices.Contains(SearchHas, v) {
+			return fmt.Errorf("invalid has filter %q: use %s", v, strings.Join(SearchHas, ", "))
+		}
+	}
+	for _, v := range q.Is {
+		if !slices.Contains(SearchIs, v) {
+			return fmt.Errorf("invalid is filter %q: use %s", v, strings.Join(SearchIs, ", "))
+		}
+	}
+
+	if q.During != "" && !duringYearMonth.MatchString(q.During) && !slices.Contains(duringWords, strings.ToLower(q.During)) {
+		return fmt.Errorf("invalid during %q: use a year (2024), a month (2024-05, may), or today, yesterday, week, month, year", q.During)
+	}
+	if !q.On.IsZero() && (!q.Before.IsZero() || !q.After.IsZero() || q.During != "") {
+		return fmt.Errorf("on cannot be combined with before, after or during")
+	}
+	if !q.Before.IsZero() && !q.After.IsZero() && searchDate(q.After) >= searchDate(q.Before) {
+		return fmt.Errorf("after (%s) must be earlier than before (%s)", searchDate(q.After), searchDate(q.Before))
+	}
+
+	return nil
+}
+
+// String compiles q into Slack's search syntax, for example
+// `deploy in:#ops from:<@U024BE7LH> after:2024-04-30 has:link`.
+func (q SearchQuery) String() string {
+	var parts []string
+
+	if q.Exact && len(q.Terms) > 0 {
+		parts = append(parts, `"`+strings.Join(q.Terms, " ")+`"`)
+	} else {
+		parts = append(parts, q.Terms...)
+	}
+
+	for _, in := range q.In {
+		in = strings.TrimPrefix(in, "#")
+		switch {
+		case IsUserID(in):
+			parts = append(parts, "in:<@"+in+">")
+		case IsConversationID(in):
+			parts = append(parts, "in:<#"+in+">")
+		default:
+			parts = append(parts, "in:#"+in)
+		}
+	}
+	for _, from := range q.From {
+		parts = append(parts, "from:"+searchUser(from))
+	}
+	for _, to := range q.To {
+		parts = append(parts, "to:"+searchUser(to))
+	}
+
+	if !q.Before.IsZero() {
+		parts = append(parts, "before:"+searchDate(q.Before))
+	}
+	if !q.After.IsZero() {
+		parts = append(parts, "after:"+searchDate(q.After))
+	}
+	if !q.On.IsZero() {
+		parts = append(parts, "on:"+searchDate(q.On))
+	}
+	if q.During != "" {
+		parts = append(parts, "during:"+strings.ToLower(q.During))
+	}
+
+	for _, has := range q.Has {
+		parts = append(parts, "has:"+has)
+	}
+	for _, is := range q.Is {
+		parts = append(parts, "is:"+is)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func searchUser(user string) string {
+	user = strings.TrimPrefix(user, "@")
+	if IsUserID(user) {
+		return "<@" + user + ">"
+	}
+	return "@" + user
+}
+
+func searchDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
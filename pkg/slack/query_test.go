@@ -0,0 +1,69 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchQuery(t *testing.T) {
+	date := func(s string) time.Time {
+		t, _ := time.Parse("2006-01-02", s)
+		return t
+	}
+
+	t.Run("should compile modifiers", func(t *testing.T) {
+		q := SearchQuery{
+			Terms:  []string{"deploy", "failed"},
+			In:     []string{"#ops", "C024BE91L", "U024BE7LH"},
+			From:   []string{"U0G9QF9C6", "@alice"},
+			To:     []string{"bob"},
+			After:  date("2024-04-30"),
+			Before: date("2024-06-01"),
+			Has:    []string{"link", "pin"},
+			Is:     []string{"thread"},
+		}
+
+		want := "deploy failed in:#ops in:<#C024BE91L> in:<@U024BE7LH> from:<@U0G9QF9C6> from:@alice to:@bob before:2024-06-01 after:2024-04-30 has:link has:pin is:thread"
+		if got := q.String(); got != want {
+			t.Errorf("unexpected query:\n got: %s\nwant: %s", got, want)
+		}
+		if err := q.Validate(); err != nil {
+			t.Errorf("expected valid query, got: %v", err)
+		}
+	})
+
+	t.Run("should quote exact phrases", func(t *testing.T) {
+		q := SearchQuery{Terms: []string{"out", "of", "memory"}, Exact: true, On: date("2024-05-01"), During: "May"}
+		if got := q.String(); got != `"out of memory" on:2024-05-01 during:may` {
+			t.Errorf("unexpected query: %s", got)
+		}
+	})
+
+	t.Run("should reject invalid queries", func(t *testing.T) {
+		tests := map[string]SearchQuery{
+			"empty":                {},
+			"must not be empty":    {Terms: []string{" "}},
+			"double quotes":        {Terms: []string{`say "hi"`}, Exact: true},
+			"needs search terms":   {Exact: true, In: []string{"ops"}},
+			"invalid has filter":   {Terms: []string{"x"}, Has: []string{"links"}},
+			"invalid is filter":    {Terms: []string{"x"}, Is: []string{"starred"}},
+			"invalid during":       {Terms: []string{"x"}, During: "2024-13"},
+			"cannot be combined":   {Terms: []string{"x"}, On: date("2024-05-01"), After: date("2024-04-01")},
+			"must be earlier than": {Terms: []string{"x"}, After: date("2024-05-01"), Before: date("2024-05-01")},
+		}
+		for want, q := range tests {
+			err := q.Validate()
+			if err == nil || !strings.Contains(err.Error(), want) {
+				t.Errorf("expected error containing %q for %+v, got: %v", want, q, err)
+			}
+		}
+	})
+
+	t.Run("should allow filters without terms", func(t *testing.T) {
+		q := SearchQuery{From: []string{"alice"}, During: "2024"}
+		if err := q.Validate(); err != nil {
+			t.Errorf("expected valid query, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,244 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Tier is a Slack Web API rate limit tier.
+// See https://api.slack.com/apis/rate-limits
+type Tier int
+
+// Rate limit tiers. TierPostMessage is the special limit of chat.postMessage.
+const (
+	Tier1 Tier = iota + 1
+	Tier2
+	Tier3
+	Tier4
+	TierPostMessage
+)
+
+// requests per minute allowed by each tier
+var tierLimits = map[Tier]int{
+	Tier1:           1,
+	Tier2:           20,
+	Tier3:           50,
+	Tier4:           100,
+	TierPostMessage: 60,
+}
+
+var methodTiers = map[string]Tier{
+	"apps.connections.open":    Tier1,
+	"auth.test":                Tier4,
+	"chat.postMessage":         TierPostMessage,
+	"conversations.list":       Tier2,
+	"conversations.history":    Tier3,
+	"conversations.replies":    Tier3,
+	"conversations.info":       Tier3,
+	"conversations.open":       Tier3,
+	"conversations.create":     Tier2,
+	"conversations.archive":    Tier2,
+	"conversations.unarchive":  Tier2,
+	"conversations.rename":     Tier2,
+	"conversations.setTopic":   Tier2,
+	"conversations.setPurpose": Tier2,
+	"conversations.join":       Tier3,
+	"conversations.leave":      Tier3,
+	"conversations.invite":     Tier3,
+	"conversations.kick":       Tier3,
+	"conversations.members":    Tier4,
+	"emoji.list":               Tier2,
+	"search.all":               Tier2,
+	"search.files":             Tier2,
+	"search.messages":          Tier2,
+	"users.list":               Tier2,
+	"users.info":               Tier4,
+}
+
+// unpacedMethods are not spaced out by the limiter and only wait for a
+// Backoff. Socket Mode re-opens its connection with apps.connections.open
+// whenever Slack asks it to reconnect, and pacing it at Tier 1 would leave
+// tail without events for up to a minute.
+var unpacedMethods = map[string]bool{
+	"apps.connections.open": true,
+}
+
+// defaultTier is used for methods missing from methodTiers.
+const defaultTier = Tier3
+
+// MethodTier returns the rate limit tier of a Slack API method.
+func MethodTier(method string) Tier {
+	if tier, ok := methodTiers[method]; ok {
+		return tier
+	}
+	return defaultTier
+}
+
+type bucket struct {
+	tokens       float64
+	burst        float64
+	perSecond    float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+// RateLimiter spaces out requests per API method according to Slack's
+// tiers, allowing a short burst before throttling. It is safe for
+// concurrent use and can be shared between clients using the same token.
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// NewRateLimiter returns a limiter with an empty bucket per method.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (l *RateLimiter) bucket(method string, now time.Time) *bucket {
+	b, ok := l.buckets[method]
+	if !ok {
+		perMinute := tierLimits[MethodTier(method)]
+		burst := float64(perMinute / 4)
+		if burst < 1 {
+			burst = 1
+		}
+		b = &bucket{
+			tokens:    burst,
+			burst:     burst,
+			perSecond: float64(perMinute) / 60,
+			last:      now,
+		}
+		l.buckets[method] = b
+	}
+	return b
+}
+
+// reserve takes a token for method and returns how long the caller has to
+// wait before using it.
+func (l *RateLimiter) reserve(method string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b := l.bucket(method, now)
+	if unpacedMethods[method] {
+		return max(b.blockedUntil.Sub(now), 0)
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * b.perSecond
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens--
+
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.perSecond * float64(time.Second))
+	}
+	if blocked := b.blockedUntil.Sub(now); blocked > wait {
+		wait = blocked
+	}
+	return wait
+}
+
+// Wait blocks until a request to method is allowed or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, method string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return sleepContext(ctx, l.reserve(method))
+}
+
+// Backoff blocks all requests to method for d, typically the Retry-After
+// duration of a 429 response.
+func (l *RateLimiter) Backoff(method string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b := l.bucket(method, now)
+	if until := now.Add(d); until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+}
+
+const maxRetryDelay = 30 * time.Second
+
+// retryDelay reports whether a failed attempt should be retried and how long
+// to wait first. Rate limited requests are always safe to retry because
+// Slack did not process them; transient server and network errors are only
+// retried for GET requests so that messages are never posted twice.
+func (c *Client) retryDelay(method string, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err != nil {
+		if method != http.MethodGet || !isTransient(err) {
+			return 0, false
+		}
+		return c.backoff(attempt), true
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		return c.backoff(attempt), true
+	case resp.StatusCode >= http.StatusInternalServerError && method == http.MethodGet:
+		return c.backoff(attempt), true
+	}
+
+	return 0, false
+}
+
+// backoff returns an exponentially growing delay with jitter so that
+// concurrent clients do not retry in lockstep.
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.retryBase << attempt
+	if delay <= 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	half := int64(delay / 2)
+	if half <= 0 {
+		return delay
+	}
+	return time.Duration(half + rand.Int64N(half+1))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+	return true
+}
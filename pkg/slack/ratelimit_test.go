@@ -0,0 +1,200 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMethodTier(t *testing.T) {
+	tests := []struct {
+		method   string
+		expected Tier
+	}{
+		{"conversations.list", Tier2},
+		{"search.messages", Tier2},
+		{"conversations.history", Tier3},
+		{"auth.test", Tier4},
+		{"chat.postMessage", TierPostMessage},
+		{"unknown.method", defaultTier},
+	}
+
+	for _, test := range tests {
+		if tier := MethodTier(test.method); tier != test.expected {
+			t.Errorf("MethodTier(%s) = %d, expected %d", test.method, tier, test.expected)
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("should allow a burst and then throttle", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		limiter := NewRateLimiter()
+		limiter.now = func() time.Time { return now }
+
+		// Tier2: 20/分、バースト5
+		for i := 0; i < 5; i++ {
+			if wait := limiter.reserve("conversations.list"); wait != 0 {
+				t.Fatalf("expected no wait for request %d, got: %v", i+1, wait)
+			}
+		}
+
+		if wait := limiter.reserve("conversations.list"); wait != 3*time.Second {
+			t.Errorf("expected 3s wait after burst, got: %v", wait)
+		}
+
+		if wait := limiter.reserve("auth.test"); wait != 0 {
+			t.Errorf("expected other methods to be unaffected, got: %v", wait)
+		}
+	})
+
+	t.Run("should not pace socket mode reconnects", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		limiter := NewRateLimiter()
+		limiter.now = func() time.Time { return now }
+
+		for i := 0; i < 3; i++ {
+			if wait := limiter.reserve("apps.connections.open"); wait != 0 {
+				t.Fatalf("expected no wait for reconnect %d, got: %v", i+1, wait)
+			}
+		}
+
+		limiter.Backoff("apps.connections.open", 5*time.Second)
+		if wait := limiter.reserve("apps.connections.open"); wait != 5*time.Second {
+			t.Errorf("expected Retry-After to be honored, got: %v", wait)
+		}
+	})
+
+	t.Run("should honor backoff", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		limiter := NewRateLimiter()
+		limiter.now = func() time.Time { return now }
+
+		limiter.Backoff("search.messages", 10*time.Second)
+		if wait := limiter.reserve("search.messages"); wait != 10*time.Second {
+			t.Errorf("expected 10s wait, got: %v", wait)
+		}
+	})
+}
+
+func TestRetry(t *testing.T) {
+	newTestClient := func(server *httptest.Server) *Client {
+		client := NewClient("test-token",
+			WithBaseURL(server.URL+"/api"),
+			WithTransport(server.Client().Transport),
+			WithRateLimiter(nil),
+		)
+		client.sleep = func(context.Context, time.Duration) error { return nil }
+		return client
+	}
+
+	t.Run("should retry rate limited requests", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts < 3 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}))
+		defer server.Close()
+
+		client := newTestClient(server)
+		var delays []time.Duration
+		client.sleep = func(_ context.Context, d time.Duration) error {
+			delays = append(delays, d)
+			return nil
+		}
+
+		if err := client.TestAuth(); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if attempts != 3 {
+			t.Errorf("expected 3 attempts, got: %d", attempts)
+		}
+		if len(delays) != 2 || delays[0] != time.Second {
+			t.Errorf("expected two 1s delays from Retry-After, got: %v", delays)
+		}
+	})
+
+	t.Run("should retry server errors for GET requests", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}))
+		defer server.Close()
+
+		if err := newTestClient(server).TestAuth(); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if attempts != 2 {
+			t.Errorf("expected 2 attempts, got: %d", attempts)
+		}
+	})
+
+	t.Run("should not retry server errors for POST requests", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		if err := newTestClient(server).PostMessage("general", "hello"); err == nil {
+			t.Error("expected error for server error")
+		}
+		if attempts != 1 {
+			t.Errorf("expected 1 attempt, got: %d", attempts)
+		}
+	})
+
+	t.Run("should give up after max retries", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		if err := newTestClient(server).TestAuth(); err == nil {
+			t.Error("expected error after retries are exhausted")
+		}
+		if attempts != DefaultMaxRetries+1 {
+			t.Errorf("expected %d attempts, got: %d", DefaultMaxRetries+1, attempts)
+		}
+	})
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := NewRateLimiter()
+	limiter.now = func() time.Time { return now }
+	limiter.Backoff("search.messages", time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := limiter.Wait(ctx, "search.messages"); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got: %v", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	client := NewClient("test-token")
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := client.backoff(attempt)
+		if delay <= 0 || delay > maxRetryDelay {
+			t.Errorf("backoff(%d) = %v, expected within (0, %v]", attempt, delay, maxRetryDelay)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	conversationIDPattern = regexp.MustCompile(`^[CGD][A-Z0-9]{6,}$`)
+	userIDPattern         = regexp.MustCompile(`^[UW][A-Z0-9]{6,}$`)
+)
+
+// IsConversationID reports whether s looks like a conversation ID such as
+// "C024BE91L" rather than a channel name.
+func IsConversationID(s string) bool {
+	return conversationIDPattern.MatchString(s)
+}
+
+// IsUserID reports whether s looks like a user ID such as "U024BE7LH".
+func IsUserID(s string) bool {
+	return userIDPattern.MatchString(s)
+}
+
+// NotFoundError is returned by Resolver when a name matches no channel or
+// user. Suggestions holds similar names, best match first.
+type NotFoundError struct {
+	// Kind is "channel" or "user".
+	Kind string
+	// Query is the reference as given, e.g. "#genral".
+	Query       string
+	Suggestions []string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found: %s", e.Kind, e.Query)
+}
+
+// Resolver maps the ways people refer to conversations and users to their
+// IDs: "#name", "name", "C024BE91L", "@user" and "U024BE7LH". The channel
+// and user directories are fetched once on first use and kept in memory,
+// so a single Resolver can serve many lookups. A name missing from a
+// cached directory is looked up again in a fresh one, so recently created
+// channels and new members are found. Tokens without the groups:read scope
+// can only resolve public channels by name.
+type Resolver struct {
+	client *Client
+
+	mu       sync.Mutex
+	channels []Channel
+	users    []User
+	// channelsCached and usersCached report that the directory came from
+	// the client's cache and may be out of date.
+	channelsCached bool
+	usersCached    bool
+}
+
+// NewResolver returns a Resolver that looks names up through client.
+func NewResolver(client *Client) *Resolver {
+	return &Resolver{client: client}
+}
+
+// ResolveChannelID returns the conversation ID for ref. IDs are returned
+// as they are without a request; "@user" opens a direct message.
+func (r *Resolver) ResolveChannelID(ctx context.Context, ref string) (string, error) {
+	if IsConversationID(ref) {
+		return ref, nil
+	}
+
+	channel, err := r.ResolveChannel(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	return channel.ID, nil
+}
+
+// ResolveChannel returns the conversation ref refers to. Unlike
+// ResolveChannelID it always looks the conversation up, so Name is set for
+// channels given by ID too.
+func (r *Resolver) ResolveChannel(ctx context.Context, ref string) (*Channel, error) {
+	if strings.HasPrefix(ref, "@") {
+		user, err := r.ResolveUser(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		return r.client.OpenConversation(ctx, user.ID)
+	}
+
+	channels, err := r.loadChannels(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if IsConversationID(ref) {
+		for i := range channels {
+			if channels[i].ID == ref {
+				return &channels[i], nil
+			}
+		}
+		// 一覧に無い DM などは conversations.info で取得する
+		return r.client.GetConversationInfo(ctx, ref)
+	}
+
+	name := strings.TrimPrefix(ref, "#")
+	if channel := findChannel(channels, name); channel != nil {
+		return channel, nil
+	}
+	if r.channelsCached {
+		// キャッシュ後に作られたチャンネルかもしれないので取得し直す
+		if channels, err = r.loadChannels(ctx, true); err != nil {
+			return nil, err
+		}
+		if channel := findChannel(channels, name); channel != nil {
+			return channel, nil
+		}
+	}
+
+	names := make([]string, 0, len(channels))
+	for _, ch := range channels {
+		if ch.Name != "" {
+			names = append(names, ch.Name)
+		}
+	}
+	suggestions := suggest(name, names, 3)
+	for i, s := range suggestions {
+		suggestions[i] = "#" + s
+	}
+
+	return nil, &NotFoundError{Kind: "channel", Query: ref, Suggestions: suggestions}
+}
+
+// ResolveUser returns the user ref refers to: a user ID, or a user name
+// or display name with or without "@".
+func (r *Resolver) ResolveUser(ctx context.Context, ref string) (*User, error) {
+	name := strings.TrimPrefix(ref, "@")
+
+	if IsUserID(name) {
+		return r.client.GetUserInfo(ctx, name)
+	}
+
+	users, err := r.loadUsers(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if user := findUser(users, name); user != nil {
+		return user, nil
+	}
+	if r.usersCached {
+		// キャッシュ後に参加したユーザーかもしれないので取得し直す
+		if users, err = r.loadUsers(ctx, true); err != nil {
+			return nil, err
+		}
+		if user := findUser(users, name); user != nil {
+			return user, nil
+		}
+	}
+
+	names := make([]string, 0, len(users))
+	for _, u := range users {
+		if !u.Deleted {
+			names = append(names, u.Name)
+		}
+	}
+	suggestions := suggest(name, names, 3)
+	for i, s := range suggestions {
+		suggestions[i] = "@" + s
+	}
+
+	return nil, &NotFoundError{Kind: "user", Query: ref, Suggestions: suggestions}
+}
+
+// findChannel returns the channel named name, preferring an exact match
+// over a case-insensitive one.
+func findChannel(channels []Channel, name string) *Channel {
+	for i := range channels {
+		if channels[i].Name == name {
+			return &channels[i]
+		}
+	}
+	for i := range channels {
+		if strings.EqualFold(channels[i].Name, name) {
+			return &channels[i]
+		}
+	}
+	return nil
+}
+
+// findUser returns the active user whose user name, display name or real
+// name is name, in that order of preference.
+func findUser(users []User, name string) *User {
+	matchers := []func(User) string{
+		func(u User) string { return u.Name },
+		func(u User) string { return u.Profile.DisplayName },
+		func(u User) string { return u.RealName },
+	}
+	for _, field := range matchers {
+		for i := range users {
+			if !users[i].Deleted && strings.EqualFold(field(users[i]), name) {
+				return &users[i]
+			}
+		}
+	}
+	return nil
+}
+
+// loadChannels returns the channel directory. refresh drops cached
+// channel lists and fetches the directory again.
+func (r *Resolver) loadChannels(ctx context.Context, refresh bool) ([]Channel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.channels != nil && !refresh {
+		return r.channels, nil
+	}
+	if refresh {
+		r.client.invalidate("channels")
+	}
+
+	options := ListChannelsOptions{
+		AllChannels:     true,
+		IncludeArchived: true,
+		Types:           []string{"public_channel", "private_channel"},
+	}
+	channels, hit, err := r.client.listChannels(ctx, options)
+	if IsErrorCode(err, "missing_scope") {
+		// groups:read の無いトークンでは公開チャンネルだけから探す
+		options.Types = []string{"public_channel"}
+		channels, hit, err = r.client.listChannels(ctx, options)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if channels == nil {
+		channels = []Channel{}
+	}
+
+	r.channels = channels
+	r.channelsCached = hit
+	return channels, nil
+}
+
+// loadUsers returns the user directory. refresh drops the cached user list
+// and fetches the directory again.
+func (r *Resolver) loadUsers(ctx context.Context, refresh bool) ([]User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.users != nil && !refresh {
+		return r.users, nil
+	}
+	if refresh {
+		r.client.invalidate("users")
+	}
+
+	users, hit, err := r.client.listUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if users == nil {
+		users = []User{}
+	}
+
+	r.users = users
+	r.usersCached = hit
+	return users, nil
+}
+
+// suggest returns up to n candidates that are close to name, either by
+// edit distance or because one contains the other.
+func suggest(name string, candidates []string, n int) []string {
+	name = strings.ToLower(name)
+	maxDistance := max(2, len(name)/3)
+
+	type scored struct {
+		name     string
+		distance int
+	}
+
+	var matches []scored
+	for _, candidate := range candidates {
+		lower := strings.ToLower(candidate)
+		distance := levenshtein(name, lower)
+		if distance > maxDistance && (len(name) < 3 || !strings.Contains(lower, name)) {
+			continue
+		}
+		matches = append(matches, scored{candidate, distance})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	suggestions := make([]string, 0, n)
+	for _, m := range matches {
+		if len(suggestions) == n {
+			break
+		}
+		suggestions = append(suggestions, m.name)
+	}
+	return suggestions
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
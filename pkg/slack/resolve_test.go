@@ -0,0 +1,181 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func newResolverTestServer(t *testing.T, calls map[string]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+
+		var response map[string]interface{}
+		switch r.URL.Path {
+		case "/conversations.list":
+			if types := r.URL.Query().Get("types"); types != "public_channel,private_channel" {
+				t.Errorf("expected public and private channels, got: %s", types)
+			}
+			response = map[string]interface{}{
+				"ok": true,
+				"channels": []map[string]interface{}{
+					{"id": "C1000001", "name": "general"},
+					{"id": "C1000002", "name": "random"},
+					{"id": "G1000003", "name": "secret-project"},
+				},
+			}
+		case "/conversations.info":
+			response = map[string]interface{}{
+				"ok":      true,
+				"channel": map[string]interface{}{"id": r.URL.Query().Get("channel")},
+			}
+		case "/users.list":
+			response = map[string]interface{}{
+				"ok": true,
+				"members": []map[string]interface{}{
+					{"id": "U1000001", "name": "alice", "profile": map[string]string{"display_name": "Alice Liddell"}},
+					{"id": "U1000002", "name": "bob"},
+				},
+			}
+		case "/conversations.open":
+			body, _ := io.ReadAll(r.Body)
+			var data map[string]interface{}
+			json.Unmarshal(body, &data)
+			if data["users"] != "U1000001" {
+				t.Errorf("expected users U1000001, got: %v", data["users"])
+			}
+			response = map[string]interface{}{"ok": true, "channel": map[string]interface{}{"id": "D1000001"}}
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func TestResolver(t *testing.T) {
+	calls := map[string]int{}
+	server := newResolverTestServer(t, calls)
+	defer server.Close()
+
+	resolver := NewResolver(NewClient("test-token", WithBaseURL(server.URL), WithRateLimiter(nil)))
+	ctx := context.Background()
+
+	tests := []struct {
+		ref      string
+		expected string
+	}{
+		{"#general", "C1000001"},
+		{"random", "C1000002"},
+		{"Secret-Project", "G1000003"},
+		{"C9999999", "C9999999"},
+		{"@alice", "D1000001"},
+		{"@Alice Liddell", "D1000001"},
+	}
+
+	for _, test := range tests {
+		id, err := resolver.ResolveChannelID(ctx, test.ref)
+		if err != nil {
+			t.Errorf("ResolveChannelID(%s) returned error: %v", test.ref, err)
+			continue
+		}
+		if id != test.expected {
+			t.Errorf("ResolveChannelID(%s) = %s, expected %s", test.ref, id, test.expected)
+		}
+	}
+
+	t.Run("should fetch directories once", func(t *testing.T) {
+		if calls["/conversations.list"] != 1 || calls["/users.list"] != 1 {
+			t.Errorf("expected one list call each, got: %v", calls)
+		}
+	})
+
+	t.Run("should look up channels given by ID", func(t *testing.T) {
+		channel, err := resolver.ResolveChannel(ctx, "C1000002")
+		if err != nil || channel.Name != "random" {
+			t.Errorf("expected #random, got: %+v (%v)", channel, err)
+		}
+
+		channel, err = resolver.ResolveChannel(ctx, "D2000000")
+		if err != nil || channel.ID != "D2000000" || calls["/conversations.info"] != 1 {
+			t.Errorf("expected conversations.info lookup, got: %+v (%v)", channel, err)
+		}
+	})
+
+	t.Run("should suggest similar channel names", func(t *testing.T) {
+		_, err := resolver.ResolveChannelID(ctx, "#genral")
+
+		var notFound *NotFoundError
+		if !errors.As(err, &notFound) {
+			t.Fatalf("expected NotFoundError, got: %v", err)
+		}
+		if err.Error() != "channel not found: #genral" {
+			t.Errorf("unexpected message: %s", err)
+		}
+		if !reflect.DeepEqual(notFound.Suggestions, []string{"#general"}) {
+			t.Errorf("unexpected suggestions: %v", notFound.Suggestions)
+		}
+	})
+
+	t.Run("should suggest similar user names", func(t *testing.T) {
+		_, err := resolver.ResolveUser(ctx, "@alcie")
+
+		var notFound *NotFoundError
+		if !errors.As(err, &notFound) || notFound.Kind != "user" {
+			t.Fatalf("expected user NotFoundError, got: %v", err)
+		}
+		if !reflect.DeepEqual(notFound.Suggestions, []string{"@alice"}) {
+			t.Errorf("unexpected suggestions: %v", notFound.Suggestions)
+		}
+	})
+}
+
+func TestResolverWithoutGroupsScope(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		types := r.URL.Query().Get("types")
+		requested = append(requested, types)
+		if types != "public_channel" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "missing_scope", "needed": "groups:read"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":       true,
+			"channels": []map[string]interface{}{{"id": "C1000001", "name": "general"}},
+		})
+	}))
+	defer server.Close()
+
+	resolver := NewResolver(NewClient("test-token", WithBaseURL(server.URL), WithRateLimiter(nil)))
+	id, err := resolver.ResolveChannelID(context.Background(), "#general")
+	if err != nil || id != "C1000001" {
+		t.Fatalf("expected C1000001, got: %s (%v)", id, err)
+	}
+	if !reflect.DeepEqual(requested, []string{"public_channel,private_channel", "public_channel"}) {
+		t.Errorf("expected fallback to public channels, got: %v", requested)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"general", "general-announcements", "random", "dev-backend", "dev-frontend"}
+
+	tests := []struct {
+		name     string
+		expected []string
+	}{
+		{"genral", []string{"general"}},
+		{"dev-fronend", []string{"dev-frontend"}},
+		{"announce", []string{"general-announcements"}},
+		{"zzz", []string{}},
+	}
+
+	for _, test := range tests {
+		if got := suggest(test.name, candidates, 3); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("suggest(%s) = %v, expected %v", test.name, got, test.expected)
+		}
+	}
+}
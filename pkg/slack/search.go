@@ -0,0 +1,372 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"time"
+)
+
+// Sort orders accepted by SearchOptions.Sort.
+const (
+	SearchSortTimestamp = "timestamp"
+	SearchSortScore     = "score"
+)
+
+var (
+	// searchPageSize is the number of matches requested per page.
+	searchPageSize = 100
+	// searchMaxPages is the last page search.messages serves, so a single
+	// query yields at most searchPageSize*searchMaxPages matches.
+	searchMaxPages = 100
+)
+
+// SearchOptions configures SearchContext, SearchMessages and the file and
+// combined searches.
+type SearchOptions struct {
+	// MaxResults caps the number of matches returned. Defaults to 20 for
+	// SearchMessages; 0 means no limit for SearchAllMessages.
+	MaxResults int
+	// Sort is SearchSortTimestamp (the default) or SearchSortScore.
+	Sort string
+	// Ascending returns the oldest, or least relevant, matches first.
+	Ascending bool
+	// Location is the time zone SearchAllMessages splits date windows in,
+	// which should be the one the query's dates were given in. Defaults to
+	// time.Local.
+	Location *time.Location
+	// ProgressFunc is called after every page with the number of matches
+	// fetched so far and MaxResults.
+	ProgressFunc func(current, total int)
+}
+
+// Search returns up to 20 messages matching query.
+func (c *Client) Search(query string) (*SearchResult, error) {
+	return c.SearchWithOptions(query, SearchOptions{MaxResults: 20})
+}
+
+// SearchWithCount returns up to count messages matching query.
+func (c *Client) SearchWithCount(query string, count int) (*SearchResult, error) {
+	return c.SearchWithOptions(query, SearchOptions{MaxResults: count})
+}
+
+// SearchWithOptions is SearchContext with context.Background.
+func (c *Client) SearchWithOptions(query string, options SearchOptions) (*SearchResult, error) {
+	return c.SearchContext(context.Background(), query, options)
+}
+
+// SearchContext searches messages page by page. If ctx is cancelled between
+// pages, the matches fetched so far are returned together with the
+// context's error.
+func (c *Client) SearchContext(ctx context.Context, query string, options SearchOptions) (*SearchResult, error) {
+	matches, err := Collect(c.SearchMessages(ctx, query, options))
+	if err != nil && ctx.Err() == nil {
+		return nil, err
+	}
+	return newSearchResult(matches), err
+}
+
+// SearchAllContext is SearchContext for SearchAllMessages.
+func (c *Client) SearchAllContext(ctx context.Context, query SearchQuery, options SearchOptions) (*SearchResult, error) {
+	matches, err := Collect(c.SearchAllMessages(ctx, query, options))
+	if err != nil && ctx.Err() == nil {
+		return nil, err
+	}
+	return newSearchResult(matches), err
+}
+
+// SearchFilesContext is SearchContext for SearchFiles.
+func (c *Client) SearchFilesContext(ctx context.Context, query string, options SearchOptions) (*FileSearchResult, error) {
+	matches, err := Collect(c.SearchFiles(ctx, query, options))
+	if err != nil && ctx.Err() == nil {
+		return nil, err
+	}
+	return newFileSearchResult(matches), err
+}
+
+// SearchAllFilesContext is SearchContext for SearchAllFiles.
+func (c *Client) SearchAllFilesContext(ctx context.Context, query SearchQuery, options SearchOptions) (*FileSearchResult, error) {
+	matches, err := Collect(c.SearchAllFiles(ctx, query, options))
+	if err != nil && ctx.Err() == nil {
+		return nil, err
+	}
+	return newFileSearchResult(matches), err
+}
+
+// SearchMessages streams matches from search.messages, fetching pages as
+// they are consumed. Slack serves at most 100 pages per query; use
+// SearchAllMessages to go further.
+func (c *Client) SearchMessages(ctx context.Context, query string, options SearchOptions) iter.Seq2[Message, error] {
+	return searchPages[Message](ctx, c, "search.messages", "messages", query, options)
+}
+
+// SearchFiles streams matches from search.files like SearchMessages.
+func (c *Client) SearchFiles(ctx context.Context, query string, options SearchOptions) iter.Seq2[File, error] {
+	return searchPages[File](ctx, c, "search.files", "files", query, options)
+}
+
+// SearchAllMessages streams matches of query past the page limit of
+// search.messages. Matches are sorted by timestamp; whenever a query
+// reaches the last page, it continues with a new query bounded by the date
+// of the last match. Windows overlap by a day, so matches are deduplicated
+// by channel and timestamp. MaxResults of 0 streams every match.
+//
+// Dates are bounded in the local time zone. If a single day has more
+// matches than one query can return, an error is yielded after that day's
+// reachable matches.
+func (c *Client) SearchAllMessages(ctx context.Context, query SearchQuery, options SearchOptions) iter.Seq2[Message, error] {
+	return searchWindows(query, options, func(query string, options SearchOptions) iter.Seq2[Message, error] {
+		return c.SearchMessages(ctx, query, options)
+	}, func(msg Message) (string, string) {
+		return msg.Channel.ID + "/" + msg.TS, msg.TS
+	})
+}
+
+// SearchAllFiles is SearchAllMessages for search.files. Files are
+// deduplicated by ID.
+func (c *Client) SearchAllFiles(ctx context.Context, query SearchQuery, options SearchOptions) iter.Seq2[File, error] {
+	return searchWindows(query, options, func(query string, options SearchOptions) iter.Seq2[File, error] {
+		return c.SearchFiles(ctx, query, options)
+	}, func(file File) (string, string) {
+		return file.ID, Timestamp(file.CreatedTime())
+	})
+}
+
+// SearchMessagesAndFilesContext searches messages and files at once with
+// search.all. MaxResults applies to messages and files separately, and
+// ProgressFunc receives the number of both fetched so far. Pages are
+// fetched until both are exhausted, up to Slack's page limit. If ctx is
+// cancelled, the matches fetched so far are returned together with the
+// context's error.
+func (c *Client) SearchMessagesAndFilesContext(ctx context.Context, query string, options SearchOptions) (*CombinedSearchResult, error) {
+	maxResults := options.MaxResults
+	if maxResults <= 0 {
+		maxResults = 20 // デフォルト
+	}
+
+	var messages []Message
+	var files []File
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return newCombinedSearchResult(messages, files), err
+		}
+
+		var response struct {
+			Messages searchMatches[Message] `json:"messages"`
+			Files    searchMatches[File]    `json:"files"`
+		}
+		if err := c.call(ctx, "GET", "search.all?"+searchParams(query, options, page).Encode(), nil, &response); err != nil {
+			if ctx.Err() != nil {
+				return newCombinedSearchResult(messages, files), err
+			}
+			return nil, fmt.Errorf("search failed: %w", err)
+		}
+
+		matches := response.Messages.Matches
+		messages = append(messages, matches[:min(len(matches), maxResults-len(messages))]...)
+		fileMatches := response.Files.Matches
+		files = append(files, fileMatches[:min(len(fileMatches), maxResults-len(files))]...)
+
+		if options.ProgressFunc != nil {
+			options.ProgressFunc(len(messages)+len(files), 2*maxResults)
+		}
+
+		// どちらかにまだ続きがあれば次のページを取得する
+		moreMessages := len(messages) < maxResults && page < response.Messages.Pagination.PageCount
+		moreFiles := len(files) < maxResults && page < response.Files.Pagination.PageCount
+		if (!moreMessages && !moreFiles) || page >= searchMaxPages {
+			return newCombinedSearchResult(messages, files), nil
+		}
+	}
+}
+
+// searchMatches is the matches object of search responses.
+type searchMatches[T any] struct {
+	Matches    []T    `json:"matches"`
+	Total      int    `json:"total"`
+	Pagination Paging `json:"pagination"`
+}
+
+func searchParams(query string, options SearchOptions, page int) url.Values {
+	sort := options.Sort
+	if sort == "" {
+		sort = SearchSortTimestamp
+	}
+	sortDir := "desc"
+	if options.Ascending {
+		sortDir = "asc"
+	}
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("sort", sort)
+	params.Set("sort_dir", sortDir)
+	params.Set("page", fmt.Sprintf("%d", page))
+	params.Set("count", fmt.Sprintf("%d", searchPageSize))
+	return params
+}
+
+// searchPages streams the matches of a search method, which are returned
+// under key in its response.
+func searchPages[T any](ctx context.Context, c *Client, method, key, query string, options SearchOptions) iter.Seq2[T, error] {
+	maxResults := options.MaxResults
+	if maxResults <= 0 {
+		maxResults = 20 // デフォルト
+	}
+
+	fetch := func(ctx context.Context, cursor Cursor) ([]T, Cursor, error) {
+		page := cursor.Page
+		if page == 0 {
+			page = 1
+		}
+
+		var response map[string]json.RawMessage
+		if err := c.call(ctx, "GET", method+"?"+searchParams(query, options, page).Encode(), nil, &response); err != nil {
+			return nil, Cursor{}, fmt.Errorf("search failed: %w", err)
+		}
+
+		var matches searchMatches[T]
+		if raw, ok := response[key]; ok {
+			if err := json.Unmarshal(raw, &matches); err != nil {
+				return nil, Cursor{}, fmt.Errorf("failed to parse response: %w", err)
+			}
+		}
+
+		// 次のページがあるかチェック
+		var next Cursor
+		if page < matches.Pagination.PageCount && page < searchMaxPages {
+			next.Page = page + 1
+		}
+
+		return matches.Matches, next, nil
+	}
+
+	return Paginate(ctx, fetch, PaginateOptions{
+		Limit:        maxResults,
+		ProgressFunc: options.ProgressFunc,
+	})
+}
+
+// searchWindows streams the matches of search past the page limit, as
+// described at SearchAllMessages. key returns the deduplication key and
+// Slack timestamp of a match.
+func searchWindows[T any](query SearchQuery, options SearchOptions, search func(query string, options SearchOptions) iter.Seq2[T, error], key func(T) (string, string)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		if options.Sort != "" && options.Sort != SearchSortTimestamp {
+			yield(zero, fmt.Errorf("searching past %d matches requires sorting by timestamp", searchPageSize*searchMaxPages))
+			return
+		}
+
+		seen := map[string]bool{}
+		count := 0
+		window := query
+
+		for {
+			windowOptions := SearchOptions{
+				Sort:      SearchSortTimestamp,
+				Ascending: options.Ascending,
+				// 上限まで取得して次のウィンドウが必要か判定する
+				MaxResults: searchPageSize * searchMaxPages,
+			}
+			if options.ProgressFunc != nil {
+				windowOptions.ProgressFunc = func(int, int) {
+					options.ProgressFunc(count, options.MaxResults)
+				}
+			}
+
+			fetched := 0
+			var lastTS string
+			for match, err := range search(window.String(), windowOptions) {
+				if err != nil {
+					yield(zero, err)
+					return
+				}
+				fetched++
+
+				id, ts := key(match)
+				lastTS = ts
+				if seen[id] {
+					continue
+				}
+				seen[id] = true
+
+				if !yield(match, nil) {
+					return
+				}
+				count++
+				if options.MaxResults > 0 && count >= options.MaxResults {
+					if options.ProgressFunc != nil {
+						options.ProgressFunc(count, options.MaxResults)
+					}
+					return
+				}
+			}
+
+			// 上限に届かなければこのウィンドウで全件取得できている
+			if fetched < searchPageSize*searchMaxPages {
+				return
+			}
+
+			next, err := nextSearchWindow(window, lastTS, options.Ascending, options.Location)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			window = next
+		}
+	}
+}
+
+// nextSearchWindow narrows window to the matches at or beyond the day of
+// lastTS, the timestamp of the final match of a full query, in loc.
+func nextSearchWindow(window SearchQuery, lastTS string, ascending bool, loc *time.Location) (SearchQuery, error) {
+	t, err := ParseTimestamp(lastTS)
+	if err != nil {
+		return window, err
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	t = t.In(loc)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+
+	// before: と after: は日付を含まないので、前後1日ずらして当日を含める
+	full := fmt.Errorf("more than %d matches on %s: narrow the search to get them all", searchPageSize*searchMaxPages, searchDate(day))
+	if !window.On.IsZero() {
+		return window, full
+	}
+	if ascending {
+		after := day.AddDate(0, 0, -1)
+		if !window.After.IsZero() && !after.After(window.After) {
+			return window, full
+		}
+		window.After = after
+	} else {
+		before := day.AddDate(0, 0, 1)
+		if !window.Before.IsZero() && !before.Before(window.Before) {
+			return window, full
+		}
+		window.Before = before
+	}
+	return window, nil
+}
+
+func newSearchResult(matches []Message) *SearchResult {
+	return &SearchResult{Matches: matches, Total: len(matches), Pagination: singlePage(len(matches))}
+}
+
+func newFileSearchResult(matches []File) *FileSearchResult {
+	return &FileSearchResult{Matches: matches, Total: len(matches), Pagination: singlePage(len(matches))}
+}
+
+func newCombinedSearchResult(messages []Message, files []File) *CombinedSearchResult {
+	return &CombinedSearchResult{Messages: *newSearchResult(messages), Files: *newFileSearchResult(files)}
+}
+
+// singlePage describes n collected matches as one page.
+func singlePage(n int) Paging {
+	return Paging{TotalCount: n, Page: 1, PerPage: n, PageCount: 1, First: 1, Last: n}
+}
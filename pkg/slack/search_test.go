@@ -0,0 +1,283 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newSearchServer serves search.messages over messages, honoring the
+// before:, after:, sort_dir, page and count parameters.
+func newSearchServer(t *testing.T, messages []Message, queries *[]string) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		*queries = append(*queries, params.Get("query")+" sort="+params.Get("sort")+" "+params.Get("sort_dir"))
+
+		var matches []Message
+		for _, msg := range messages {
+			t, _ := ParseTimestamp(msg.TS)
+			date := t.Format("2006-01-02")
+			ok := true
+			for _, part := range strings.Fields(params.Get("query")) {
+				if bound, found := strings.CutPrefix(part, "before:"); found && date >= bound {
+					ok = false
+				}
+				if bound, found := strings.CutPrefix(part, "after:"); found && date <= bound {
+					ok = false
+				}
+			}
+			if ok {
+				matches = append(matches, msg)
+			}
+		}
+		slices.SortFunc(matches, func(a, b Message) int { return strings.Compare(b.TS, a.TS) })
+		if params.Get("sort_dir") == "asc" {
+			slices.Reverse(matches)
+		}
+
+		page, _ := strconv.Atoi(params.Get("page"))
+		count, _ := strconv.Atoi(params.Get("count"))
+		start, end := min((page-1)*count, len(matches)), min(page*count, len(matches))
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"messages": map[string]interface{}{
+				"matches":    matches[start:end],
+				"total":      len(matches),
+				"pagination": map[string]int{"per_page": count, "total_count": len(matches), "page": page, "page_count": (len(matches) + count - 1) / count},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return NewClient("test-token",
+		WithBaseURL(server.URL+"/api"),
+		WithTransport(server.Client().Transport),
+		WithRateLimiter(nil),
+	)
+}
+
+func TestSearchAllMessages(t *testing.T) {
+	// 1クエリあたり 2件 x 2ページ までに制限する
+	searchPageSize, searchMaxPages = 2, 2
+	defer func() { searchPageSize, searchMaxPages = 100, 100 }()
+
+	message := func(day, hour int, channel string) Message {
+		ts := Timestamp(time.Date(2024, 5, day, hour, 0, 0, 0, time.Local))
+		return Message{Text: "deploy", TS: ts, Channel: ChannelRef{ID: channel}}
+	}
+	messages := []Message{
+		message(1, 9, "C1"), message(1, 10, "C1"),
+		message(2, 9, "C1"), message(2, 10, "C2"),
+		message(3, 9, "C1"), message(3, 10, "C1"),
+	}
+
+	t.Run("should walk past the page limit in date windows", func(t *testing.T) {
+		var queries []string
+		client := newSearchServer(t, messages, &queries)
+
+		matches, err := Collect(client.SearchAllMessages(context.Background(), SearchQuery{Terms: []string{"deploy"}}, SearchOptions{}))
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if len(matches) != 6 {
+			t.Fatalf("expected 6 unique matches, got: %d", len(matches))
+		}
+		for i := 1; i < len(matches); i++ {
+			if matches[i].TS >= matches[i-1].TS {
+				t.Errorf("expected newest first, got: %s after %s", matches[i].TS, matches[i-1].TS)
+			}
+		}
+		if !slices.Contains(queries, "deploy before:2024-05-03 sort=timestamp desc") {
+			t.Errorf("expected a query bounded by the last match, got: %q", queries)
+		}
+	})
+
+	t.Run("should walk forward when ascending", func(t *testing.T) {
+		var queries []string
+		client := newSearchServer(t, messages, &queries)
+
+		matches, err := Collect(client.SearchAllMessages(context.Background(), SearchQuery{Terms: []string{"deploy"}}, SearchOptions{Ascending: true, MaxResults: 5}))
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if len(matches) != 5 || matches[0].TS != messages[0].TS || matches[4].TS != messages[4].TS {
+			t.Errorf("unexpected matches: %+v", matches)
+		}
+		if !slices.Contains(queries, "deploy after:2024-05-01 sort=timestamp asc") {
+			t.Errorf("expected a query bounded by the last match, got: %q", queries)
+		}
+	})
+
+	t.Run("should fail when one day exceeds the limit", func(t *testing.T) {
+		var queries []string
+		busy := append(slices.Clone(messages), message(3, 11, "C1"), message(3, 12, "C1"))
+		client := newSearchServer(t, busy, &queries)
+
+		matches, err := Collect(client.SearchAllMessages(context.Background(), SearchQuery{Terms: []string{"deploy"}}, SearchOptions{}))
+		if err == nil || !strings.Contains(err.Error(), "more than 4 matches on 2024-05-03") {
+			t.Errorf("expected window error, got: %v", err)
+		}
+		if len(matches) != 4 {
+			t.Errorf("expected the reachable matches, got: %d", len(matches))
+		}
+	})
+
+	t.Run("should split windows by day in the given time zone", func(t *testing.T) {
+		tokyo := time.FixedZone("JST", 9*60*60)
+		lastTS := Timestamp(time.Date(2024, 5, 3, 1, 0, 0, 0, tokyo)) // 2024-05-02 16:00 UTC
+
+		for loc, want := range map[*time.Location]string{tokyo: "before:2024-05-04", time.UTC: "before:2024-05-03"} {
+			window, err := nextSearchWindow(SearchQuery{Terms: []string{"deploy"}}, lastTS, false, loc)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if got := window.String(); got != "deploy "+want {
+				t.Errorf("%s: expected %q, got: %q", loc, "deploy "+want, got)
+			}
+		}
+	})
+
+	t.Run("should require timestamp sort", func(t *testing.T) {
+		var queries []string
+		client := newSearchServer(t, messages, &queries)
+
+		_, err := Collect(client.SearchAllMessages(context.Background(), SearchQuery{Terms: []string{"deploy"}}, SearchOptions{Sort: SearchSortScore}))
+		if err == nil || len(queries) != 0 {
+			t.Errorf("expected error without requests, got: %v (%q)", err, queries)
+		}
+	})
+}
+
+func TestSearchMessagesSort(t *testing.T) {
+	var queries []string
+	client := newSearchServer(t, nil, &queries)
+
+	if _, err := client.SearchContext(context.Background(), "deploy", SearchOptions{Sort: SearchSortScore, Ascending: true}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(queries) != 1 || queries[0] != "deploy sort=score asc" {
+		t.Errorf("unexpected queries: %q", queries)
+	}
+}
+
+func TestSearchFiles(t *testing.T) {
+	searchPageSize = 2
+	defer func() { searchPageSize = 100 }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/search.files" {
+			t.Errorf("expected path '/api/search.files', got: %s", r.URL.Path)
+		}
+
+		files := []map[string]interface{}{
+			{"id": "F1", "name": "diagram.png", "filetype": "png", "size": 2048, "user": "U1", "created": 1714521600, "channels": []string{"C1"}, "ims": []string{"D1"}},
+			{"id": "F2", "name": "notes.pdf", "filetype": "pdf"},
+		}
+		if r.URL.Query().Get("page") == "2" {
+			files = []map[string]interface{}{{"id": "F3", "name": "spec.docx", "filetype": "docx"}}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"files": map[string]interface{}{
+				"matches":    files,
+				"total":      3,
+				"pagination": map[string]int{"page": 1, "page_count": 2},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token",
+		WithBaseURL(server.URL+"/api"),
+		WithTransport(server.Client().Transport),
+		WithRateLimiter(nil),
+	)
+
+	result, err := client.SearchFilesContext(context.Background(), "diagram", SearchOptions{MaxResults: 10})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if result.Total != 3 || result.Matches[2].ID != "F3" {
+		t.Fatalf("expected files from both pages, got: %+v", result)
+	}
+	file := result.Matches[0]
+	if file.User != "U1" || file.Size != 2048 || file.CreatedTime().Unix() != 1714521600 {
+		t.Errorf("unexpected file: %+v", file)
+	}
+	if shared := file.SharedIn(); !slices.Equal(shared, []string{"C1", "D1"}) {
+		t.Errorf("unexpected channels: %v", shared)
+	}
+}
+
+func TestSearchMessagesAndFiles(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/search.all" {
+			t.Errorf("expected path '/api/search.all', got: %s", r.URL.Path)
+		}
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		// メッセージは1ページ、ファイルは2ページ
+		messages := []map[string]interface{}{}
+		if page == "1" {
+			messages = append(messages, map[string]interface{}{"text": "see the diagram", "ts": "1714521600.000100"})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"messages": map[string]interface{}{
+				"matches":    messages,
+				"pagination": map[string]int{"page_count": 1},
+			},
+			"files": map[string]interface{}{
+				"matches":    []map[string]interface{}{{"id": "F" + page, "name": "diagram.png"}},
+				"pagination": map[string]int{"page_count": 2},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token",
+		WithBaseURL(server.URL+"/api"),
+		WithTransport(server.Client().Transport),
+		WithRateLimiter(nil),
+	)
+
+	var progress []int
+	result, err := client.SearchMessagesAndFilesContext(context.Background(), "diagram", SearchOptions{
+		MaxResults:   10,
+		ProgressFunc: func(current, total int) { progress = append(progress, current) },
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if result.Messages.Total != 1 || result.Files.Total != 2 || result.Files.Matches[1].ID != "F2" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if !slices.Equal(pages, []string{"1", "2"}) || !slices.Equal(progress, []int{2, 3}) {
+		t.Errorf("unexpected pages %v or progress %v", pages, progress)
+	}
+}
+
+func TestSearchResultJSON(t *testing.T) {
+	data, err := json.Marshal(SearchResult{Pagination: singlePage(1)})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !strings.Contains(string(data), `"paging":{"total_count":1`) {
+		t.Errorf("expected pagination under \"paging\", got: %s", data)
+	}
+}
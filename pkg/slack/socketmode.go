@@ -0,0 +1,186 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+)
+
+// SocketModeEnvelope is a message received over a Socket Mode connection.
+type SocketModeEnvelope struct {
+	Type         string          `json:"type"`
+	EnvelopeID   string          `json:"envelope_id,omitempty"`
+	Payload      json.RawMessage `json:"payload,omitempty"`
+	RetryAttempt int             `json:"retry_attempt,omitempty"`
+	Reason       string          `json:"reason,omitempty"`
+}
+
+// EventCallback is the payload of an "events_api" envelope.
+type EventCallback struct {
+	TeamID  string `json:"team_id"`
+	EventID string `json:"event_id"`
+	Event   Event  `json:"event"`
+}
+
+// Event is an Events API event. Only the fields shared by message events
+// are decoded.
+type Event struct {
+	Type     string `json:"type"`
+	Subtype  string `json:"subtype,omitempty"`
+	Channel  string `json:"channel"`
+	User     string `json:"user"`
+	Text     string `json:"text"`
+	TS       string `json:"ts"`
+	ThreadTS string `json:"thread_ts,omitempty"`
+	BotID    string `json:"bot_id,omitempty"`
+}
+
+// Message converts a message event into a Message.
+func (e Event) Message() Message {
+	return Message{
+		Type:     e.Type,
+		Subtype:  e.Subtype,
+		Text:     e.Text,
+		User:     e.User,
+		BotID:    e.BotID,
+		Channel:  ChannelRef{ID: e.Channel},
+		TS:       e.TS,
+		ThreadTS: e.ThreadTS,
+	}
+}
+
+// OpenSocketModeConnection calls apps.connections.open and returns the
+// WebSocket URL to connect to. The client must use an app-level token
+// (xapp-) with the connections:write scope.
+func (c *Client) OpenSocketModeConnection(ctx context.Context) (string, error) {
+	var response struct {
+		URL string `json:"url"`
+	}
+
+	if err := c.call(ctx, "POST", "apps.connections.open", nil, &response); err != nil {
+		return "", fmt.Errorf("failed to open socket mode connection: %w", err)
+	}
+
+	return response.URL, nil
+}
+
+// RunSocketMode connects to Slack with Socket Mode and calls handle for
+// every Events API event until ctx is cancelled. Envelopes are
+// acknowledged before handle is called, events Slack delivers again are
+// handled once, and the connection is re-opened whenever Slack asks to
+// disconnect or the WebSocket drops. The client must use an app-level
+// token.
+func (c *Client) RunSocketMode(ctx context.Context, handle func(Event)) error {
+	// 再接続の前後で再送されることもあるので接続をまたいで覚えておく
+	seen := &recentEvents{ids: map[string]bool{}}
+	failures := 0
+	for {
+		wsURL, err := c.OpenSocketModeConnection(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		received, err := c.serveSocketMode(ctx, wsURL, seen, handle)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err == nil || received {
+			failures = 0
+			continue
+		}
+
+		// 接続直後に切れ続ける場合は間隔を空けて再接続し、最終的には諦める
+		failures++
+		if failures > c.maxRetries {
+			return fmt.Errorf("socket mode connection failed: %w", err)
+		}
+		if err := c.sleep(ctx, c.backoff(failures-1)); err != nil {
+			return err
+		}
+	}
+}
+
+// serveSocketMode reads envelopes from a single connection. It reports
+// whether any event was received, and returns nil when Slack requested a
+// reconnect.
+func (c *Client) serveSocketMode(ctx context.Context, wsURL string, seen *recentEvents, handle func(Event)) (bool, error) {
+	dialer := websocket.Dialer{
+		Proxy: http.ProxyFromEnvironment,
+	}
+	conn, _, err := dialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close()
+
+	// ctx がキャンセルされたら読み込み待ちを解除する
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	received := false
+	for {
+		var envelope SocketModeEnvelope
+		if err := conn.ReadJSON(&envelope); err != nil {
+			return received, fmt.Errorf("failed to read from socket: %w", err)
+		}
+
+		if envelope.EnvelopeID != "" {
+			ack := map[string]string{"envelope_id": envelope.EnvelopeID}
+			if err := conn.WriteJSON(ack); err != nil {
+				return received, fmt.Errorf("failed to acknowledge envelope: %w", err)
+			}
+		}
+
+		switch envelope.Type {
+		case "disconnect":
+			return received, nil
+		case "events_api":
+			var callback EventCallback
+			if err := json.Unmarshal(envelope.Payload, &callback); err != nil {
+				continue
+			}
+			received = true
+			if seen.add(callback.key()) {
+				handle(callback.Event)
+			}
+		}
+	}
+}
+
+// key identifies the event across retries, which carry the same event ID.
+func (e EventCallback) key() string {
+	if e.EventID != "" {
+		return e.EventID
+	}
+	return e.Event.Channel + "/" + e.Event.TS
+}
+
+// maxRecentEvents bounds how many event keys recentEvents remembers.
+const maxRecentEvents = 1000
+
+// recentEvents remembers the keys of recently handled events.
+type recentEvents struct {
+	ids   map[string]bool
+	order []string
+}
+
+// add records key and reports whether it was not seen before.
+func (r *recentEvents) add(key string) bool {
+	if r.ids[key] {
+		return false
+	}
+	if len(r.order) == maxRecentEvents {
+		delete(r.ids, r.order[0])
+		r.order = r.order[1:]
+	}
+	r.ids[key] = true
+	r.order = append(r.order, key)
+	return true
+}
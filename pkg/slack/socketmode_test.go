@@ -0,0 +1,167 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestRunSocketMode(t *testing.T) {
+	acked := make(chan string, 1)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/apps.connections.open":
+			if r.Header.Get("Authorization") != "Bearer xapp-test" {
+				t.Errorf("expected app token, got: %s", r.Header.Get("Authorization"))
+			}
+			response := map[string]interface{}{
+				"ok":  true,
+				"url": "ws" + strings.TrimPrefix(server.URL, "http") + "/link",
+			}
+			json.NewEncoder(w).Encode(response)
+
+		case "/link":
+			upgrader := websocket.Upgrader{}
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				t.Errorf("failed to upgrade: %v", err)
+				return
+			}
+			defer conn.Close()
+
+			conn.WriteJSON(map[string]interface{}{"type": "hello"})
+			conn.WriteJSON(map[string]interface{}{
+				"type":        "events_api",
+				"envelope_id": "env-1",
+				"payload": map[string]interface{}{
+					"team_id": "T1",
+					"event": map[string]interface{}{
+						"type":    "message",
+						"channel": "C1234567890",
+						"user":    "U1",
+						"text":    "deploy finished",
+						"ts":      "1700000001.000000",
+					},
+				},
+			})
+
+			var ack map[string]string
+			if err := conn.ReadJSON(&ack); err == nil {
+				acked <- ack["envelope_id"]
+			}
+
+			// クライアントが切断するまで待つ
+			conn.ReadMessage()
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("xapp-test",
+		WithBaseURL(server.URL+"/api"),
+		WithTransport(server.Client().Transport),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var events []Event
+	err := client.RunSocketMode(ctx, func(event Event) {
+		events = append(events, event)
+		cancel()
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got: %d", len(events))
+	}
+
+	msg := events[0].Message()
+	if msg.Channel.ID != "C1234567890" || msg.Text != "deploy finished" {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+
+	if envelopeID := <-acked; envelopeID != "env-1" {
+		t.Errorf("expected ack for 'env-1', got: %s", envelopeID)
+	}
+}
+
+func TestRunSocketModeRetries(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/apps.connections.open":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "url": "ws" + strings.TrimPrefix(server.URL, "http") + "/link"})
+
+		case "/link":
+			conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+			if err != nil {
+				t.Errorf("failed to upgrade: %v", err)
+				return
+			}
+			defer conn.Close()
+
+			// 2 通目は ack が遅れたときの再送
+			for i, event := range []struct{ envelope, id, text string }{
+				{"env-1", "Ev1", "first"},
+				{"env-2", "Ev1", "first"},
+				{"env-3", "Ev2", "second"},
+			} {
+				conn.WriteJSON(map[string]interface{}{
+					"type":          "events_api",
+					"envelope_id":   event.envelope,
+					"retry_attempt": i % 2,
+					"payload": map[string]interface{}{
+						"event_id": event.id,
+						"event":    map[string]interface{}{"type": "message", "channel": "C1234567890", "text": event.text, "ts": "1700000001.000000"},
+					},
+				})
+			}
+			conn.ReadMessage()
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("xapp-test", WithBaseURL(server.URL+"/api"), WithTransport(server.Client().Transport))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var texts []string
+	client.RunSocketMode(ctx, func(event Event) {
+		texts = append(texts, event.Text)
+		if event.Text == "second" {
+			cancel()
+		}
+	})
+
+	if strings.Join(texts, ",") != "first,second" {
+		t.Errorf("expected retried events to be handled once, got: %v", texts)
+	}
+}
+
+func TestOpenSocketModeConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "not_allowed_token_type"})
+	}))
+	defer server.Close()
+
+	client := NewClient("xoxb-test",
+		WithBaseURL(server.URL+"/api"),
+		WithTransport(server.Client().Transport),
+	)
+
+	err := client.RunSocketMode(context.Background(), func(Event) {})
+	if !IsErrorCode(err, "not_allowed_token_type") {
+		t.Errorf("expected not_allowed_token_type, got: %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package slack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseTimestamp converts a Slack message timestamp such as
+// "1712345678.123456" into a time.Time.
+func ParseTimestamp(ts string) (time.Time, error) {
+	secPart, fracPart, _ := strings.Cut(ts, ".")
+
+	sec, err := strconv.ParseInt(secPart, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", ts)
+	}
+
+	var usec int64
+	if fracPart != "" {
+		// 小数部はマイクロ秒（6桁）に揃える
+		if len(fracPart) > 6 {
+			fracPart = fracPart[:6]
+		}
+		fracPart += strings.Repeat("0", 6-len(fracPart))
+		if usec, err = strconv.ParseInt(fracPart, 10, 64); err != nil {
+			return time.Time{}, fmt.Errorf("invalid timestamp %q", ts)
+		}
+	}
+
+	return time.Unix(sec, usec*int64(time.Microsecond)), nil
+}
+
+// Timestamp formats t as a Slack timestamp with microsecond precision, for
+// use as the oldest or latest bound of history requests.
+func Timestamp(t time.Time) string {
+	return fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/int(time.Microsecond))
+}
@@ -0,0 +1,63 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+)
+
+// ListUsersContext returns every member of the workspace. With a Cache
+// configured, the list is served from and stored in it.
+func (c *Client) ListUsersContext(ctx context.Context) ([]User, error) {
+	users, _, err := c.listUsers(ctx)
+	return users, err
+}
+
+// listUsers is ListUsersContext that also reports whether the list came
+// from the cache.
+func (c *Client) listUsers(ctx context.Context) ([]User, bool, error) {
+	return cached(ctx, c, "users", func(ctx context.Context) ([]User, error) {
+		return Collect(c.Users(ctx))
+	})
+}
+
+// Users streams the members of the workspace from users.list.
+func (c *Client) Users(ctx context.Context) iter.Seq2[User, error] {
+	fetch := func(ctx context.Context, cursor Cursor) ([]User, Cursor, error) {
+		params := url.Values{}
+		params.Set("limit", "200")
+		if cursor.Token != "" {
+			params.Set("cursor", cursor.Token)
+		}
+
+		var response struct {
+			Members          []User           `json:"members"`
+			ResponseMetadata ResponseMetadata `json:"response_metadata"`
+		}
+
+		if err := c.call(ctx, "GET", "users.list?"+params.Encode(), nil, &response); err != nil {
+			return nil, Cursor{}, fmt.Errorf("failed to list users: %w", err)
+		}
+
+		return response.Members, Cursor{Token: response.ResponseMetadata.NextCursor}, nil
+	}
+
+	return Paginate(ctx, fetch, PaginateOptions{})
+}
+
+// GetUserInfo returns a single user from users.info.
+func (c *Client) GetUserInfo(ctx context.Context, userID string) (*User, error) {
+	params := url.Values{}
+	params.Set("user", userID)
+
+	var response struct {
+		User User `json:"user"`
+	}
+
+	if err := c.call(ctx, "GET", "users.info?"+params.Encode(), nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get user %s: %w", userID, err)
+	}
+
+	return &response.User, nil
+}